@@ -0,0 +1,104 @@
+package cache
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/essentialkaos/check"
+
+	"github.com/essentialkaos/ek/v14/events"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type CacheSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&CacheSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *CacheSuite) TestGroup(c *C) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		<-release
+		return int(calls.Add(1)), nil
+	}
+
+	c.Assert(g.Go("test", fn), Equals, true)
+	c.Assert(g.Go("test", fn), Equals, false)
+
+	for range 5 {
+		wg.Go(func() {
+			v, err := g.Do("test", fn)
+			c.Check(err, IsNil)
+			c.Check(v, Equals, 1)
+		})
+	}
+
+	time.Sleep(time.Second / 20)
+	close(release)
+	wg.Wait()
+
+	c.Assert(calls.Load(), Equals, int32(1))
+
+	v, err := g.Do("test", fn)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, 2)
+
+	_, err = g.Do("test", func() (int, error) { panic("ERROR") })
+	c.Assert(err, ErrorMatches, "function panicked: ERROR")
+}
+
+func (s *CacheSuite) TestGroupNil(c *C) {
+	var g *Group[string, int]
+
+	_, err := g.Do("test", func() (int, error) { return 1, nil })
+	c.Assert(err, NotNil)
+	c.Assert(g.Go("test", func() (int, error) { return 1, nil }), Equals, false)
+
+	_, err = (&Group[string, int]{}).Do("test", nil)
+	c.Assert(err, NotNil)
+}
+
+func (s *CacheSuite) TestReason(c *C) {
+	c.Assert(REASON_EXPIRED.String(), Equals, "expired")
+	c.Assert(REASON_DELETED.String(), Equals, "deleted")
+	c.Assert(REASON_FLUSHED.String(), Equals, "flushed")
+	c.Assert(REASON_CAPACITY.String(), Equals, "capacity")
+	c.Assert(Reason(99).String(), Equals, "unknown")
+}
+
+func (s *CacheSuite) TestDispatchTo(c *C) {
+	d := events.NewDispatcher()
+	ch := make(chan any, 2)
+
+	d.AddHandler(EV_EXPIRED, func(payload any) { ch <- payload })
+	d.AddHandler(EV_EVICTED, func(payload any) { ch <- payload })
+
+	handler := DispatchTo[string, int](d)
+
+	handler("test", 1, REASON_EXPIRED)
+	c.Assert(<-ch, DeepEquals, Eviction{"test", 1, REASON_EXPIRED})
+
+	handler("test", 2, REASON_DELETED)
+	c.Assert(<-ch, DeepEquals, Eviction{"test", 2, REASON_DELETED})
+}
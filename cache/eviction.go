@@ -0,0 +1,73 @@
+package cache
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"github.com/essentialkaos/ek/v14/events"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	REASON_EXPIRED  Reason = iota // Item expired
+	REASON_DELETED                // Item deleted using Delete method
+	REASON_FLUSHED                // Item removed by Flush method
+	REASON_CAPACITY               // Item evicted due to size limits
+)
+
+const (
+	EV_EXPIRED = "cache.expired" // Item expired
+	EV_EVICTED = "cache.evicted" // Item removed for any other reason
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Reason is reason of item removal
+type Reason uint8
+
+// Eviction is payload of eviction events
+type Eviction struct {
+	Key    any
+	Value  any
+	Reason Reason
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns name of removal reason
+func (r Reason) String() string {
+	switch r {
+	case REASON_EXPIRED:
+		return "expired"
+	case REASON_DELETED:
+		return "deleted"
+	case REASON_FLUSHED:
+		return "flushed"
+	case REASON_CAPACITY:
+		return "capacity"
+	}
+
+	return "unknown"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DispatchTo returns eviction handler which dispatches events with [Eviction]
+// payload using given dispatcher. Expired items are dispatched as [EV_EXPIRED]
+// event, all other as [EV_EVICTED] event.
+func DispatchTo[K comparable, V any](d *events.Dispatcher) func(key K, value V, reason Reason) {
+	return func(key K, value V, reason Reason) {
+		ev := EV_EVICTED
+
+		if reason == REASON_EXPIRED {
+			ev = EV_EXPIRED
+		}
+
+		d.Dispatch(ev, Eviction{key, value, reason})
+	}
+}
@@ -0,0 +1,158 @@
+package fs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Codec is interface for encoding and decoding cache items
+type Codec interface {
+	// Encode encodes data and writes it to w
+	Encode(w io.Writer, data any) error
+
+	// Decode reads data from r and decodes it
+	Decode(r io.Reader) (any, error)
+}
+
+// Compressor is interface for compressing cache items
+type Compressor interface {
+	// NewWriter returns writer which compresses data written to w
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+
+	// NewReader returns reader which decompresses data read from r
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GobCodec is codec which uses GOB encoding. Custom types stored as cache
+// items must be registered using gob.Register.
+type GobCodec struct{}
+
+// JSONCodec is codec which uses JSON encoding. Items are decoded into generic
+// types (map[string]any, []any, float64, string, bool).
+type JSONCodec struct{}
+
+// RawCodec is codec which stores []byte and string items as is. Items are
+// decoded as []byte.
+type RawCodec struct{}
+
+// GzipCompressor is compressor which uses gzip
+type GzipCompressor struct {
+	Level int // Compression level (gzip.DefaultCompression if 0)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cacheItem is cache item
+type cacheItem struct {
+	Data any
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrUnsupportedData is returned by RawCodec if data is not []byte or string
+var ErrUnsupportedData = errors.New("raw codec supports only []byte and string data")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// validate codec and compressor interfaces
+var (
+	_ Codec      = GobCodec{}
+	_ Codec      = JSONCodec{}
+	_ Codec      = RawCodec{}
+	_ Compressor = GzipCompressor{}
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Encode encodes data into GOB format
+func (c GobCodec) Encode(w io.Writer, data any) error {
+	return gob.NewEncoder(w).Encode(&cacheItem{data})
+}
+
+// Decode decodes GOB-encoded data
+func (c GobCodec) Decode(r io.Reader) (any, error) {
+	item := &cacheItem{}
+	err := gob.NewDecoder(r).Decode(item)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return item.Data, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Encode encodes data into JSON format
+func (c JSONCodec) Encode(w io.Writer, data any) error {
+	return json.NewEncoder(w).Encode(data)
+}
+
+// Decode decodes JSON-encoded data
+func (c JSONCodec) Decode(r io.Reader) (any, error) {
+	var data any
+
+	err := json.NewDecoder(r).Decode(&data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Encode writes data as is
+func (c RawCodec) Encode(w io.Writer, data any) error {
+	var err error
+
+	switch t := data.(type) {
+	case []byte:
+		_, err = w.Write(t)
+	case string:
+		_, err = io.WriteString(w, t)
+	default:
+		return fmt.Errorf("%w (got %T)", ErrUnsupportedData, data)
+	}
+
+	return err
+}
+
+// Decode reads all data
+func (c RawCodec) Decode(r io.Reader) (any, error) {
+	return io.ReadAll(r)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewWriter returns gzip writer
+func (c GzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	level := c.Level
+
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	return gzip.NewWriterLevel(w, level)
+}
+
+// NewReader returns gzip reader
+func (c GzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
@@ -28,6 +28,20 @@ func ExampleNew() {
 	fmt.Println(c.Get("test"))
 }
 
+func ExampleNew_codec() {
+	c, _ := New(Config{
+		Dir:               "/path/to/cache",
+		DefaultExpiration: cache.DAY,
+		Codec:             JSONCodec{},
+		Compressor:        GzipCompressor{Level: 9},
+		MaxSize:           100 * 1024 * 1024, // 100 MB
+	})
+
+	c.Set("test", map[string]any{"id": 1, "name": "John"})
+
+	fmt.Println(c.Get("test"))
+}
+
 func ExampleCache_Set() {
 	c, _ := New(Config{
 		Dir:               "/path/to/cache",
@@ -97,6 +111,26 @@ func ExampleCache_GetWithExpiration() {
 	fmt.Println(item, exp.String())
 }
 
+func ExampleCache_GetOrLoad() {
+	c, _ := New(Config{
+		Dir:               "/path/to/cache",
+		DefaultExpiration: cache.HOUR,
+		ErrorExpiration:   cache.MINUTE,
+		RefreshAhead:      5 * cache.MINUTE,
+	})
+
+	item, err := c.GetOrLoad("test", func() (any, error) {
+		return "ABCD", nil
+	})
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Println(item)
+}
+
 func ExampleCache_Invalidate() {
 	c, _ := New(Config{
 		Dir:               "/path/to/cache",
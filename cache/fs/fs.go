@@ -11,12 +11,15 @@ package fs
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
-	"encoding/gob"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sync"
 	"time"
 
@@ -44,7 +47,21 @@ const MIN_CLEANUP_INTERVAL = cache.SECOND
 type Cache struct {
 	dir             string
 	expiration      cache.Duration
+	errorExpiration cache.Duration
+	refreshAhead    cache.Duration
 	validationRegex *regexp.Regexp
+	codec           Codec
+	compressor      Compressor
+	maxSize         int64
+	items           map[string]itemFile // Index of item files (nil = not loaded)
+	itemsSize       int64               // Total size of indexed item files
+	shrinkMu        sync.Mutex
+	failures        map[string]failure
+	failuresMu      sync.Mutex
+	loads           cache.Group[string, any]
+	onEvicted       func(key string, value any, reason cache.Reason)
+	onExpired       func(key string, value any)
+	handlersMu      sync.RWMutex
 	doneChan        chan struct{}
 	stopOnce        sync.Once
 	isJanitorWorks  bool
@@ -56,15 +73,41 @@ type Config struct {
 	ValidationRegexp  string
 	DefaultExpiration cache.Duration
 	CleanupInterval   cache.Duration
+	ErrorExpiration   cache.Duration // Expiration of loader errors (0 = errors are not cached)
+	RefreshAhead      cache.Duration // Period before expiration when item is refreshed in background (0 = disabled)
+	Codec             Codec          // Codec for encoding items (GobCodec by default)
+	Compressor        Compressor     // Compressor for items (nil = no compression)
+	MaxSize           int64          // Maximum total size of items in bytes (0 = unlimited)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// cacheItem is cache item
-type cacheItem struct {
-	Data any
+// failure is cached loader error
+type failure struct {
+	err    error
+	expiry time.Time
 }
 
+// itemFile contains info about item file
+type itemFile struct {
+	path  string
+	size  int64
+	ctime time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrNilCache is returned if cache struct is nil
+	ErrNilCache = errors.New("cache is nil")
+
+	// ErrNilLoader is returned if loader function is nil
+	ErrNilLoader = errors.New("loader function is nil")
+
+	// ErrInvalidKey is returned if key doesn't match validation regular expression
+	ErrInvalidKey = errors.New("invalid key")
+)
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validate storage interface
@@ -81,8 +124,18 @@ func New(config Config) (*Cache, error) {
 	}
 
 	c := &Cache{
-		dir:        config.Dir,
-		expiration: DEFAULT_EXPIRATION,
+		dir:             config.Dir,
+		expiration:      DEFAULT_EXPIRATION,
+		errorExpiration: config.ErrorExpiration,
+		refreshAhead:    config.RefreshAhead,
+		codec:           config.Codec,
+		compressor:      config.Compressor,
+		maxSize:         config.MaxSize,
+		failures:        make(map[string]failure),
+	}
+
+	if c.codec == nil {
+		c.codec = GobCodec{}
 	}
 
 	if config.DefaultExpiration != 0 {
@@ -151,32 +204,41 @@ func (c *Cache) Has(key string) bool {
 	return false
 }
 
-// Set adds or updates item in cache
+// Set adds or updates item in cache. Item expiration date is stored as file
+// modification time, so it can be read without decoding item data. If total size
+// of items exceeds size limit, the oldest items are removed.
 func (c *Cache) Set(key string, data any, expiration ...cache.Duration) bool {
 	if c == nil || data == nil || !c.isValidKey(key) {
 		return false
 	}
 
+	exp := c.expiration
+
+	if len(expiration) > 0 && expiration[0] >= MIN_EXPIRATION {
+		exp = expiration[0]
+	}
+
 	tmpFile := c.getItemPath(key, true)
 
-	if !writeItem(tmpFile, data) {
+	if !c.writeItem(tmpFile, data) {
 		return false
 	}
 
 	itemFile := c.getItemPath(key, false)
 
-	if os.Rename(tmpFile, itemFile) != nil {
+	if os.Chtimes(tmpFile, time.Time{}, time.Now().Add(exp)) != nil ||
+		os.Rename(tmpFile, itemFile) != nil {
 		os.Remove(tmpFile)
 		return false
 	}
 
-	exp := c.expiration
+	c.clearFailure(key)
 
-	if len(expiration) > 0 && expiration[0] >= MIN_EXPIRATION {
-		exp = expiration[0]
+	if c.maxSize > 0 {
+		return c.shrink(itemFile)
 	}
 
-	return os.Chtimes(itemFile, time.Time{}, time.Now().Add(exp)) == nil
+	return true
 }
 
 // Get returns item from cache
@@ -190,11 +252,12 @@ func (c *Cache) Get(key string) any {
 	}
 
 	if c.isExpired(key) {
-		c.Delete(key)
+		c.remove(c.getItemPath(key, false), cache.REASON_EXPIRED)
+		c.unindexItem(c.getItemPath(key, false))
 		return nil
 	}
 
-	return readItem(c.getItemPath(key, false))
+	return c.readItem(c.getItemPath(key, false))
 }
 
 // GetExpiration returns item expiration date
@@ -216,7 +279,43 @@ func (c *Cache) GetWithExpiration(key string) (any, time.Time) {
 
 	exp, _ := fsutil.GetMTime(c.getItemPath(key, false))
 
-	return readItem(c.getItemPath(key, false)), exp
+	return c.readItem(c.getItemPath(key, false)), exp
+}
+
+// GetOrLoad returns item from cache or loads it using given loader function and
+// adds it to cache. Concurrent loads of the same key are deduplicated. If error
+// expiration is set, loader errors are cached in memory and returned without
+// calling loader again. If refresh ahead period is set, items which are about to
+// expire are returned immediately and reloaded in background.
+func (c *Cache) GetOrLoad(key string, loader func() (any, error)) (any, error) {
+	switch {
+	case c == nil:
+		return nil, ErrNilCache
+	case loader == nil:
+		return nil, ErrNilLoader
+	case !c.isValidKey(key):
+		return nil, ErrInvalidKey
+	}
+
+	item, exp := c.GetWithExpiration(key)
+
+	if item != nil {
+		if c.refreshAhead > 0 && time.Until(exp) <= c.refreshAhead {
+			c.loads.Go(key, func() (any, error) { return c.load(key, loader) })
+		}
+
+		return item, nil
+	}
+
+	c.failuresMu.Lock()
+	fail, failed := c.failures[key]
+	c.failuresMu.Unlock()
+
+	if failed && time.Now().Before(fail.expiry) {
+		return nil, fail.err
+	}
+
+	return c.loads.Do(key, func() (any, error) { return c.load(key, loader) })
 }
 
 // Keys is an iterator over cache keys
@@ -251,7 +350,13 @@ func (c *Cache) Delete(key string) bool {
 		return false
 	}
 
-	return os.Remove(c.getItemPath(key, false)) == nil
+	c.clearFailure(key)
+
+	ok := c.remove(c.getItemPath(key, false), cache.REASON_DELETED)
+
+	c.unindexItem(c.getItemPath(key, false))
+
+	return ok
 }
 
 // Invalidate deletes all expired records
@@ -260,23 +365,37 @@ func (c *Cache) Invalidate() bool {
 		return false
 	}
 
+	now := time.Now()
+
+	c.failuresMu.Lock()
+
+	for key, fail := range c.failures {
+		if !now.Before(fail.expiry) {
+			delete(c.failures, key)
+		}
+	}
+
+	c.failuresMu.Unlock()
+
 	items := fsutil.List(c.dir, true)
 
 	if len(items) == 0 {
 		return false
 	}
-
-	now := time.Now()
 	fsutil.ListToAbsolute(c.dir, items)
 
 	for _, item := range items {
 		mtime, _ := fsutil.GetMTime(item)
 
 		if mtime.Before(now) {
-			os.Remove(item)
+			c.remove(item, cache.REASON_EXPIRED)
 		}
 	}
 
+	// Files could be changed by other processes, so the index is rebuilt
+	// on the next write
+	c.resetIndex()
+
 	return true
 }
 
@@ -286,16 +405,47 @@ func (c *Cache) Flush() bool {
 		return false
 	}
 
+	c.failuresMu.Lock()
+	c.failures = make(map[string]failure)
+	c.failuresMu.Unlock()
+
 	items := fsutil.List(c.dir, true)
 	fsutil.ListToAbsolute(c.dir, items)
 
 	for _, item := range items {
-		os.Remove(item)
+		c.remove(item, cache.REASON_FLUSHED)
 	}
 
+	c.resetIndex()
+
 	return true
 }
 
+// OnEvicted sets handler which is called for every item removed from cache
+// (expired, deleted or flushed). If handler is set, item data is read from disk
+// before removal.
+func (c *Cache) OnEvicted(handler func(key string, value any, reason cache.Reason)) {
+	if c == nil {
+		return
+	}
+
+	c.handlersMu.Lock()
+	c.onEvicted = handler
+	c.handlersMu.Unlock()
+}
+
+// OnExpired sets handler which is called for every expired item removed from
+// cache
+func (c *Cache) OnExpired(handler func(key string, value any)) {
+	if c == nil {
+		return
+	}
+
+	c.handlersMu.Lock()
+	c.onExpired = handler
+	c.handlersMu.Unlock()
+}
+
 // Stop stops janitor goroutine
 func (c *Cache) Stop() {
 	if c == nil || !c.isJanitorWorks || c.doneChan == nil {
@@ -319,6 +469,18 @@ func (c Config) Validate() error {
 		return fmt.Errorf("cleanup interval is too short (< 1s)")
 	}
 
+	if c.ErrorExpiration != 0 && c.ErrorExpiration < MIN_EXPIRATION {
+		return fmt.Errorf("error expiration is too short (< 1s)")
+	}
+
+	if c.RefreshAhead < 0 {
+		return fmt.Errorf("refresh ahead period can't be less than 0")
+	}
+
+	if c.MaxSize < 0 {
+		return fmt.Errorf("max size can't be less than 0")
+	}
+
 	err := fsutil.ValidatePerms("DRWX", c.Dir)
 
 	if err != nil {
@@ -350,6 +512,161 @@ func (c *Cache) getItemPath(key string, temporary bool) string {
 	return filepath.Join(c.dir, key)
 }
 
+// load loads item using given loader function and adds it to cache
+func (c *Cache) load(key string, loader func() (any, error)) (any, error) {
+	item, err := loader()
+
+	if err != nil {
+		if c.errorExpiration > 0 {
+			c.failuresMu.Lock()
+			c.failures[key] = failure{err, time.Now().Add(c.errorExpiration)}
+			c.failuresMu.Unlock()
+		}
+
+		return nil, err
+	}
+
+	c.Set(key, item)
+
+	return item, nil
+}
+
+// remove removes item file and passes item to eviction handlers
+func (c *Cache) remove(file string, reason cache.Reason) bool {
+	c.handlersMu.RLock()
+	onEvicted, onExpired := c.onEvicted, c.onExpired
+	c.handlersMu.RUnlock()
+
+	if onEvicted == nil && (onExpired == nil || reason != cache.REASON_EXPIRED) {
+		return os.Remove(file) == nil
+	}
+
+	value := c.readItem(file)
+
+	if os.Remove(file) != nil {
+		return false
+	}
+
+	key := filepath.Base(file)
+
+	if onExpired != nil && reason == cache.REASON_EXPIRED {
+		onExpired(key, value)
+	}
+
+	if onEvicted != nil {
+		onEvicted(key, value, reason)
+	}
+
+	return true
+}
+
+// shrink adds given item file to the index and removes the oldest items until
+// total size of items fits size limit. It returns false if given item file was
+// removed.
+func (c *Cache) shrink(file string) bool {
+	c.shrinkMu.Lock()
+	defer c.shrinkMu.Unlock()
+
+	if c.items == nil {
+		c.loadIndex()
+	}
+
+	c.indexItem(file)
+
+	if c.itemsSize <= c.maxSize {
+		return true
+	}
+
+	files := slices.Collect(maps.Values(c.items))
+
+	slices.SortStableFunc(files, func(a, b itemFile) int {
+		return a.ctime.Compare(b.ctime)
+	})
+
+	result := true
+
+	for _, item := range files {
+		if c.itemsSize <= c.maxSize {
+			break
+		}
+
+		c.remove(item.path, cache.REASON_CAPACITY)
+		c.itemsSize -= item.size
+		delete(c.items, item.path)
+
+		if item.path == file {
+			result = false
+		}
+	}
+
+	return result
+}
+
+// loadIndex reads info about all item files in cache directory. Temporary files
+// of concurrent writers are hidden, so they are not indexed.
+func (c *Cache) loadIndex() {
+	c.items = make(map[string]itemFile)
+	c.itemsSize = 0
+
+	for _, name := range fsutil.List(c.dir, true) {
+		path := filepath.Join(c.dir, name)
+		ctime, err := fsutil.GetCTime(path)
+
+		if err != nil {
+			continue
+		}
+
+		c.items[path] = itemFile{path, fsutil.GetSize(path), ctime}
+		c.itemsSize += c.items[path].size
+	}
+}
+
+// indexItem adds or updates info about given item file in the index
+func (c *Cache) indexItem(file string) {
+	c.itemsSize -= c.items[file].size
+
+	item := itemFile{file, fsutil.GetSize(file), time.Now()}
+
+	c.items[file] = item
+	c.itemsSize += item.size
+}
+
+// unindexItem removes info about given item file from the index
+func (c *Cache) unindexItem(file string) {
+	if c.maxSize <= 0 {
+		return
+	}
+
+	c.shrinkMu.Lock()
+
+	item, ok := c.items[file]
+
+	if ok {
+		c.itemsSize -= item.size
+		delete(c.items, file)
+	}
+
+	c.shrinkMu.Unlock()
+}
+
+// resetIndex drops the index, so it will be rebuilt on the next write
+func (c *Cache) resetIndex() {
+	if c.maxSize <= 0 {
+		return
+	}
+
+	c.shrinkMu.Lock()
+	c.items, c.itemsSize = nil, 0
+	c.shrinkMu.Unlock()
+}
+
+// clearFailure removes cached loader error for given key
+func (c *Cache) clearFailure(key string) {
+	c.failuresMu.Lock()
+	delete(c.failures, key)
+	c.failuresMu.Unlock()
+}
+
 // janitor is cache cleanup job
 func (c *Cache) janitor(interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -369,41 +686,75 @@ MAIN:
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// writeItem encodes data into GOB format and writes it into the file
-func writeItem(file string, data any) bool {
+// writeItem encodes data and writes it into the file
+func (c *Cache) writeItem(file string, data any) bool {
 	fd, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
 
 	if err != nil {
 		return false
 	}
 
-	defer fd.Close()
+	err = c.encodeItem(fd, data)
+
+	if fd.Close() != nil || err != nil {
+		os.Remove(file)
+		return false
+	}
+
+	return true
+}
+
+// encodeItem encodes and compresses data
+func (c *Cache) encodeItem(w io.Writer, data any) error {
+	if c.compressor == nil {
+		return c.codec.Encode(w, data)
+	}
 
-	err = gob.NewEncoder(fd).Encode(&cacheItem{data})
+	cw, err := c.compressor.NewWriter(w)
 
 	if err != nil {
-		os.Remove(file)
+		return err
 	}
 
-	return err == nil
+	err = c.codec.Encode(cw, data)
+
+	if err != nil {
+		cw.Close()
+		return err
+	}
+
+	return cw.Close()
 }
 
-// readItem reads GOB-encoded data from the file
-func readItem(file string) any {
+// readItem reads data from the file and decodes it
+func (c *Cache) readItem(file string) any {
 	fd, err := os.Open(file)
 
 	if err != nil {
 		return nil
 	}
 
-	item := &cacheItem{}
-	err = gob.NewDecoder(fd).Decode(item)
+	defer fd.Close()
+
+	var r io.Reader = fd
+
+	if c.compressor != nil {
+		cr, err := c.compressor.NewReader(fd)
+
+		if err != nil {
+			return nil
+		}
+
+		defer cr.Close()
+
+		r = cr
+	}
 
-	fd.Close()
+	data, err := c.codec.Decode(r)
 
 	if err != nil {
 		return nil
 	}
 
-	return item.Data
+	return data
 }
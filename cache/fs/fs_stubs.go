@@ -11,6 +11,7 @@ package fs
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"errors"
 	"time"
 
 	"github.com/essentialkaos/ek/v14/cache"
@@ -32,12 +33,31 @@ type Cache struct{}
 // ❗ Config is cache configuration
 type Config struct {
 	Dir               string
+	ValidationRegexp  string
 	DefaultExpiration cache.Duration
 	CleanupInterval   cache.Duration
+	ErrorExpiration   cache.Duration
+	RefreshAhead      cache.Duration
+	Codec             Codec
+	Compressor        Compressor
+	MaxSize           int64
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+var (
+	// ❗ ErrNilCache is returned if cache struct is nil
+	ErrNilCache = errors.New("cache is nil")
+
+	// ❗ ErrNilLoader is returned if loader function is nil
+	ErrNilLoader = errors.New("loader function is nil")
+
+	// ❗ ErrInvalidKey is returned if key doesn't match validation regular expression
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // validate storage interface
 var _ cache.Cache = (*Cache)(nil)
 
@@ -85,6 +105,12 @@ func (c *Cache) GetWithExpiration(key string) (any, time.Time) {
 	panic("UNSUPPORTED")
 }
 
+// ❗ GetOrLoad returns item from cache or loads it using given loader function
+// and adds it to cache
+func (c *Cache) GetOrLoad(key string, loader func() (any, error)) (any, error) {
+	panic("UNSUPPORTED")
+}
+
 // ❗ Keys is an iterator over cache keys
 func (c *Cache) Keys(yield func(k string) bool) {
 	panic("UNSUPPORTED")
@@ -110,6 +136,17 @@ func (c *Cache) Flush() bool {
 	panic("UNSUPPORTED")
 }
 
+// ❗ OnEvicted sets handler which is called for every item removed from cache
+func (c *Cache) OnEvicted(handler func(key string, value any, reason cache.Reason)) {
+	panic("UNSUPPORTED")
+}
+
+// ❗ OnExpired sets handler which is called for every expired item removed from
+// cache
+func (c *Cache) OnExpired(handler func(key string, value any)) {
+	panic("UNSUPPORTED")
+}
+
 // ❗ Stop stops janitor goroutine
 func (c *Cache) Stop() {
 	panic("UNSUPPORTED")
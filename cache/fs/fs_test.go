@@ -8,11 +8,18 @@ package fs
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"errors"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/essentialkaos/check"
+
+	"github.com/essentialkaos/ek/v14/cache"
+	"github.com/essentialkaos/ek/v14/fsutil"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -125,6 +132,223 @@ func (s *CacheSuite) TestIterators(c *C) {
 	}
 }
 
+func (s *CacheSuite) TestGetOrLoad(c *C) {
+	cache, err := New(Config{
+		DefaultExpiration: 2 * time.Second,
+		ErrorExpiration:   time.Second,
+		RefreshAhead:      time.Second,
+		Dir:               c.MkDir(),
+	})
+
+	c.Assert(err, IsNil)
+
+	_, err = cache.GetOrLoad("1", nil)
+	c.Assert(err, Equals, ErrNilLoader)
+	_, err = cache.GetOrLoad("#", func() (any, error) { return "TEST", nil })
+	c.Assert(err, Equals, ErrInvalidKey)
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+
+	loader := func() (any, error) {
+		n := calls.Add(1)
+		time.Sleep(time.Second / 16)
+		return int(n), nil
+	}
+
+	for range 10 {
+		wg.Go(func() {
+			v, err := cache.GetOrLoad("1", loader)
+			c.Check(err, IsNil)
+			c.Check(v, Equals, 1)
+		})
+	}
+
+	wg.Wait()
+
+	c.Assert(calls.Load(), Equals, int32(1))
+	c.Assert(cache.Get("1"), Equals, 1)
+
+	time.Sleep(time.Second + time.Second/10)
+
+	v, err := cache.GetOrLoad("1", loader)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, 1)
+
+	for range 50 {
+		if cache.Get("1") == 2 {
+			break
+		}
+
+		time.Sleep(time.Second / 50)
+	}
+
+	c.Assert(cache.Get("1"), Equals, 2)
+
+	errLoad := errors.New("load error")
+	errLoader := func() (any, error) { return nil, errLoad }
+
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+	_, err = cache.GetOrLoad("2", loader)
+	c.Assert(err, Equals, errLoad)
+	c.Assert(cache.Has("2"), Equals, false)
+
+	cache.Delete("2")
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+	cache.Set("2", "TEST")
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("3", errLoader)
+	c.Assert(err, Equals, errLoad)
+	cache.Flush()
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("3", errLoader)
+	c.Assert(err, Equals, errLoad)
+	cache.failures["3"] = failure{errLoad, time.Now().Add(-time.Second)}
+	cache.Invalidate()
+	c.Assert(cache.failures, HasLen, 0)
+}
+
+func (s *CacheSuite) TestEvictionHandlers(c *C) {
+	fc, err := New(Config{
+		DefaultExpiration: time.Second,
+		Dir:               c.MkDir(),
+	})
+
+	c.Assert(err, IsNil)
+
+	var evicted []string
+	var expired []string
+
+	fc.OnExpired(func(key string, value any) {
+		expired = append(expired, key)
+	})
+
+	fc.Set("1", "A")
+	fc.Set("2", "B", time.Minute)
+	fc.Delete("2")
+
+	time.Sleep(time.Second + time.Second/10)
+
+	c.Assert(fc.Get("1"), IsNil)
+	c.Assert(expired, DeepEquals, []string{"1"})
+
+	fc.OnEvicted(func(key string, value any, reason cache.Reason) {
+		evicted = append(evicted, key+":"+value.(string)+":"+reason.String())
+	})
+
+	fc.Set("1", "A")
+	fc.Set("2", "B", time.Minute)
+	fc.Set("3", "C", time.Minute)
+	fc.Delete("2")
+
+	time.Sleep(time.Second + time.Second/10)
+
+	fc.Invalidate()
+	fc.Flush()
+
+	c.Assert(evicted, DeepEquals, []string{"2:B:deleted", "1:A:expired", "3:C:flushed"})
+	c.Assert(expired, DeepEquals, []string{"1", "1"})
+}
+
+func (s *CacheSuite) TestCodecs(c *C) {
+	fc, err := New(Config{Dir: c.MkDir(), Codec: JSONCodec{}})
+
+	c.Assert(err, IsNil)
+	c.Assert(fc.Set("1", map[string]int{"a": 1}), Equals, true)
+	c.Assert(fc.Get("1"), DeepEquals, map[string]any{"a": float64(1)})
+
+	data, err := os.ReadFile(fc.dir + "/1")
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "{\"a\":1}\n")
+
+	fc, err = New(Config{Dir: c.MkDir(), Codec: RawCodec{}})
+
+	c.Assert(err, IsNil)
+	c.Assert(fc.Set("1", "TEST"), Equals, true)
+	c.Assert(fc.Set("2", []byte("ABCD")), Equals, true)
+	c.Assert(fc.Set("3", 123), Equals, false)
+	c.Assert(fc.Get("1"), DeepEquals, []byte("TEST"))
+	c.Assert(fc.Get("2"), DeepEquals, []byte("ABCD"))
+	c.Assert(fc.Has("3"), Equals, false)
+
+	fc, err = New(Config{Dir: c.MkDir(), Compressor: GzipCompressor{}})
+
+	c.Assert(err, IsNil)
+	c.Assert(fc.Set("1", strings.Repeat("TEST", 1000)), Equals, true)
+	c.Assert(fc.Get("1"), Equals, strings.Repeat("TEST", 1000))
+	c.Assert(fsutil.GetSize(fc.dir+"/1") < 1000, Equals, true)
+	c.Assert(fc.GetExpiration("1").After(time.Now()), Equals, true)
+
+	os.WriteFile(fc.dir+"/2", []byte("TEST"), 0600)
+	c.Assert(fc.readItem(fc.dir+"/2"), IsNil)
+
+	fc.compressor = GzipCompressor{Level: 100}
+	c.Assert(fc.Set("3", "TEST"), Equals, false)
+
+	err = RawCodec{}.Encode(nil, 1)
+	c.Assert(err, ErrorMatches, `raw codec supports only \[\]byte and string data \(got int\)`)
+}
+
+func (s *CacheSuite) TestMaxSize(c *C) {
+	fc, err := New(Config{Dir: c.MkDir(), Codec: RawCodec{}, MaxSize: 30})
+
+	c.Assert(err, IsNil)
+
+	var evicted []string
+
+	fc.OnEvicted(func(key string, value any, reason cache.Reason) {
+		evicted = append(evicted, key+":"+reason.String())
+	})
+
+	// File change time has coarse resolution, so we need a small delay between
+	// writes to get predictable order
+	for _, key := range []string{"1", "2", "3", "1", "4"} {
+		c.Assert(fc.Set(key, "0123456789"), Equals, true)
+		time.Sleep(time.Second / 50)
+	}
+
+	c.Assert(fc.Size(), Equals, 3)
+	c.Assert(fc.Has("2"), Equals, false)
+	c.Assert(evicted, DeepEquals, []string{"2:capacity"})
+
+	c.Assert(fc.Set("5", strings.Repeat("0", 40)), Equals, false)
+	c.Assert(fc.Has("5"), Equals, false)
+	c.Assert(fc.Size(), Equals, 0)
+	c.Assert(fc.itemsSize, Equals, int64(0))
+
+	// Temporary files of concurrent writers must be ignored
+	tmpFile := fc.getItemPath("6", true)
+	c.Assert(os.WriteFile(tmpFile, []byte(strings.Repeat("0", 40)), 0600), IsNil)
+
+	c.Assert(fc.Set("6", "0123456789"), Equals, true)
+	c.Assert(fc.Set("7", "0123456789"), Equals, true)
+	c.Assert(fc.itemsSize, Equals, int64(20))
+	c.Assert(fsutil.IsExist(tmpFile), Equals, true)
+
+	c.Assert(fc.Delete("6"), Equals, true)
+	c.Assert(fc.itemsSize, Equals, int64(10))
+	c.Assert(fc.Set("8", "0123456789"), Equals, true)
+	c.Assert(fc.Set("9", "0123456789"), Equals, true)
+	c.Assert(fc.Has("7"), Equals, true)
+	c.Assert(fc.itemsSize, Equals, int64(30))
+
+	// Index is rebuilt after invalidation
+	c.Assert(fc.Invalidate(), Equals, true)
+	c.Assert(fc.items, IsNil)
+	c.Assert(fc.Set("7", "0123456789"), Equals, true)
+	c.Assert(fc.itemsSize, Equals, int64(30))
+	c.Assert(fc.Size(), Equals, 3)
+
+	c.Assert(fc.Flush(), Equals, true)
+	c.Assert(fc.items, IsNil)
+}
+
 func (s *CacheSuite) TestNil(c *C) {
 	var cache *Cache
 
@@ -140,11 +364,16 @@ func (s *CacheSuite) TestNil(c *C) {
 	c.Assert(cache.Invalidate(), Equals, false)
 
 	cache.Stop()
+	cache.OnEvicted(nil)
+	cache.OnExpired(nil)
 
 	item, exp := cache.GetWithExpiration("1")
 	c.Assert(item, Equals, nil)
 	c.Assert(exp.IsZero(), Equals, true)
 
+	_, err := cache.GetOrLoad("1", func() (any, error) { return "TEST", nil })
+	c.Assert(err, Equals, ErrNilCache)
+
 	c.Assert(func() {
 		for range cache.Keys {
 		}
@@ -161,8 +390,8 @@ func (s *CacheSuite) TestErrors(c *C) {
 
 	c.Assert(cache.Set("1", "TEST"), Equals, true)
 	os.WriteFile(cache.dir+"/1", []byte("0000"), 0600)
-	c.Assert(readItem(cache.dir+"/1"), IsNil)
-	c.Assert(readItem(cache.dir+"/2"), IsNil)
+	c.Assert(cache.readItem(cache.dir+"/1"), IsNil)
+	c.Assert(cache.readItem(cache.dir+"/2"), IsNil)
 
 	cache.dir = "/_unknown_"
 
@@ -178,6 +407,18 @@ func (s *CacheSuite) TestConfig(c *C) {
 
 	c.Assert(err.Error(), Equals, "invalid configuration: cleanup interval is too short (< 1s)")
 
+	_, err = New(Config{ErrorExpiration: 1})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: error expiration is too short (< 1s)")
+
+	_, err = New(Config{RefreshAhead: -1})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: refresh ahead period can't be less than 0")
+
+	_, err = New(Config{MaxSize: -1})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: max size can't be less than 0")
+
 	_, err = New(Config{DefaultExpiration: time.Minute, CleanupInterval: time.Minute, Dir: "_unknown_"})
 
 	c.Assert(err.Error(), Equals, "invalid configuration: can't use given directory for cache: directory _unknown_ doesn't exist or not accessible")
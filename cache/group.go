@@ -0,0 +1,117 @@
+package cache
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Group deduplicates concurrent calls of functions with the same key. Zero value
+// is ready to use.
+type Group[K comparable, V any] struct {
+	calls map[K]*call[V]
+	mu    sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// call is in-flight function call
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Do executes given function and returns its result. If there is already
+// in-flight call with the same key, Do waits for it and returns its result.
+// Panic in function is returned as an error.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	var zero V
+
+	if g == nil || fn == nil {
+		return zero, errors.New("group or function is nil")
+	}
+
+	cl, isNew := g.getCall(key)
+
+	if !isNew {
+		cl.wg.Wait()
+		return cl.val, cl.err
+	}
+
+	g.run(key, cl, fn)
+
+	return cl.val, cl.err
+}
+
+// Go executes given function in background. It returns false if there is already
+// in-flight call with the same key.
+func (g *Group[K, V]) Go(key K, fn func() (V, error)) bool {
+	if g == nil || fn == nil {
+		return false
+	}
+
+	cl, isNew := g.getCall(key)
+
+	if !isNew {
+		return false
+	}
+
+	go g.run(key, cl, fn)
+
+	return true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCall returns in-flight call with given key or registers a new one
+func (g *Group[K, V]) getCall(key K) (*call[V], bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+
+	cl := g.calls[key]
+
+	if cl != nil {
+		return cl, false
+	}
+
+	cl = &call[V]{}
+	cl.wg.Add(1)
+	g.calls[key] = cl
+
+	return cl, true
+}
+
+// run executes function and wakes up all waiting callers
+func (g *Group[K, V]) run(key K, cl *call[V], fn func() (V, error)) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			cl.err = fmt.Errorf("function panicked: %v", r)
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+
+		cl.wg.Done()
+	}()
+
+	cl.val, cl.err = fn()
+}
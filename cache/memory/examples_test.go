@@ -8,9 +8,12 @@ package memory
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/essentialkaos/ek/v14/cache"
+	"github.com/essentialkaos/ek/v14/events"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -95,6 +98,26 @@ func ExampleCache_GetWithExpiration() {
 	fmt.Println(item, exp.String())
 }
 
+func ExampleCache_GetOrLoad() {
+	c, _ := New(Config{
+		DefaultExpiration: cache.MINUTE,
+		ErrorExpiration:   cache.SECOND,
+		RefreshAhead:      10 * cache.SECOND,
+	})
+
+	item, err := c.GetOrLoad("test", func() (any, error) {
+		return "ABCD", nil
+	})
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Println(item)
+	// Output: ABCD
+}
+
 func ExampleCache_Keys() {
 	c, _ := New(Config{
 		DefaultExpiration: cache.SECOND,
@@ -157,6 +180,101 @@ func ExampleCache_Flush() {
 	// Output: <nil>
 }
 
+func ExampleCache_OnEvicted() {
+	c, _ := New(Config{
+		DefaultExpiration: cache.MINUTE,
+		CleanupInterval:   cache.SECOND,
+	})
+
+	c.OnEvicted(func(key string, value any, reason cache.Reason) {
+		fmt.Printf("%s removed (%s)\n", key, reason)
+	})
+
+	c.Set("test", "ABCD")
+	c.Delete("test")
+
+	// Output: test removed (deleted)
+}
+
+func ExampleCache_OnExpired() {
+	c, _ := New(Config{
+		DefaultExpiration: cache.MINUTE,
+		CleanupInterval:   cache.SECOND,
+	})
+
+	// Dispatch eviction events using events dispatcher
+	d := events.NewDispatcher()
+
+	d.AddHandler(cache.EV_EXPIRED, func(payload any) {
+		e := payload.(cache.Eviction)
+		fmt.Printf("%v expired\n", e.Key)
+	})
+
+	c.OnEvicted(cache.DispatchTo[string, any](d))
+
+	// Close resources held by expired items
+	c.OnExpired(func(key string, value any) {
+		if r, ok := value.(io.Closer); ok {
+			r.Close()
+		}
+	})
+}
+
+func ExampleCache_Save() {
+	c, _ := New(Config{
+		DefaultExpiration: cache.MINUTE,
+	})
+
+	c.Set("test", "ABCD")
+
+	var buf bytes.Buffer
+
+	err := c.Save(&buf)
+
+	if err != nil {
+		fmt.Printf("Can't save snapshot: %v\n", err)
+		return
+	}
+
+	c, _ = New(Config{})
+
+	err = c.Load(&buf)
+
+	if err != nil {
+		fmt.Printf("Can't load snapshot: %v\n", err)
+		return
+	}
+
+	fmt.Println(c.Get("test"))
+	// Output: ABCD
+}
+
+func ExampleCache_SaveFile() {
+	// Cache will be loaded from snapshot on start, saved every 5 minutes
+	// and on stop
+	c, err := New(Config{
+		DefaultExpiration: cache.HOUR,
+		SnapshotFile:      "/var/cache/myapp/cache.snap",
+		SnapshotInterval:  5 * cache.MINUTE,
+	})
+
+	if err != nil {
+		fmt.Printf("Can't create cache: %v\n", err)
+		return
+	}
+
+	defer c.Stop()
+
+	c.Set("test", "ABCD")
+
+	// Snapshot also can be saved manually
+	err = c.SaveFile("/var/cache/myapp/cache.snap")
+
+	if err != nil {
+		fmt.Printf("Can't save snapshot: %v\n", err)
+	}
+}
+
 func ExampleCache_Stop() {
 	c, _ := New(Config{
 		DefaultExpiration: cache.SECOND,
@@ -167,3 +285,83 @@ func ExampleCache_Stop() {
 
 	defer c.Stop()
 }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func ExampleNewTyped() {
+	c, _ := NewTyped[string, int](TypedConfig[string]{
+		DefaultExpiration: cache.MINUTE,
+		MaxEntries:        1000,
+		Policy:            NewLFU[string](),
+	})
+
+	c.Set("test", 42)
+
+	v, ok := c.Get("test")
+
+	fmt.Println(v, ok)
+	// Output: 42 true
+}
+
+func ExampleAsCache() {
+	tc, _ := NewTyped[string, int](TypedConfig[string]{
+		DefaultExpiration: cache.MINUTE,
+	})
+
+	var c cache.Cache = AsCache(tc)
+
+	c.Set("test", 42)
+
+	fmt.Println(c.Get("test"))
+	// Output: 42
+}
+
+func ExampleTypedCache_GetOrLoad() {
+	c, _ := NewTyped[int, string](TypedConfig[int]{
+		DefaultExpiration: cache.MINUTE,
+		ErrorExpiration:   cache.SECOND,
+	})
+
+	user, err := c.GetOrLoad(1, func() (string, error) {
+		return "john", nil
+	})
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Println(user)
+	// Output: john
+}
+
+func ExampleTypedCache_SetWithCost() {
+	c, _ := NewTyped[string, []byte](TypedConfig[string]{
+		DefaultExpiration: cache.MINUTE,
+		MaxCost:           10,
+	})
+
+	c.SetWithCost("a", []byte("ABCDEF"), 6)
+	c.SetWithCost("b", []byte("GHIJ"), 4)
+	c.SetWithCost("c", []byte("KL"), 2)
+
+	fmt.Println(c.Has("a"), c.Has("b"), c.Has("c"), c.Cost())
+	// Output: false true true 6
+}
+
+func ExampleTypedCache_Stats() {
+	c, _ := NewTyped[int, string](TypedConfig[int]{
+		MaxEntries: 2,
+		Policy:     NewTinyLFU[int](2),
+	})
+
+	c.Set(1, "A")
+	c.Set(2, "B")
+	c.Get(1)
+	c.Get(3)
+
+	stats := c.Stats()
+
+	fmt.Printf("Hits: %d | Misses: %d | Evictions: %d\n", stats.Hits, stats.Misses, stats.Evictions)
+	// Output: Hits: 1 | Misses: 1 | Evictions: 0
+}
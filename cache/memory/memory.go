@@ -11,6 +11,7 @@ package memory
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"sync"
 	"time"
 
@@ -35,21 +36,59 @@ var _ cache.Cache = (*Cache)(nil)
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+var (
+	// ErrNilCache is returned if cache struct is nil
+	ErrNilCache = errors.New("cache is nil")
+
+	// ErrNilLoader is returned if loader function is nil
+	ErrNilLoader = errors.New("loader function is nil")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // Cache is in-memory cache instance
 type Cache struct {
-	data           map[string]any
-	expiry         map[string]int64
-	mu             sync.RWMutex
-	expiration     cache.Duration
-	doneChan       chan struct{}
-	stopOnce       sync.Once
-	isJanitorWorks bool
+	data            map[string]any
+	expiry          map[string]int64
+	failures        map[string]failure
+	loads           cache.Group[string, any]
+	mu              sync.RWMutex
+	expiration      cache.Duration
+	errorExpiration cache.Duration
+	refreshAhead    cache.Duration
+	onEvicted       func(key string, value any, reason cache.Reason)
+	onExpired       func(key string, value any)
+	pending         []removed[string, any]
+	doneChan        chan struct{}
+	workers         sync.WaitGroup
+	stopOnce        sync.Once
+	isJanitorWorks  bool
 }
 
 // Config is cache configuration
 type Config struct {
 	DefaultExpiration cache.Duration
 	CleanupInterval   cache.Duration
+	ErrorExpiration   cache.Duration  // Expiration of loader errors (0 = errors are not cached)
+	RefreshAhead      cache.Duration  // Period before expiration when item is refreshed in background (0 = disabled)
+	SnapshotFile      string          // Path to snapshot file (loaded on start if exists)
+	SnapshotInterval  cache.Duration  // Interval between snapshots (0 = disabled)
+	OnSnapshotError   func(err error) // Handler for errors of periodic snapshots
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// failure is cached loader error
+type failure struct {
+	err    error
+	expiry int64
+}
+
+// removed is removed item waiting for passing to eviction handlers
+type removed[K comparable, V any] struct {
+	key    K
+	value  V
+	reason cache.Reason
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -63,19 +102,39 @@ func New(config Config) (*Cache, error) {
 	}
 
 	c := &Cache{
-		expiration: DEFAULT_EXPIRATION,
-		data:       make(map[string]any),
-		expiry:     make(map[string]int64),
+		expiration:      DEFAULT_EXPIRATION,
+		errorExpiration: config.ErrorExpiration,
+		refreshAhead:    config.RefreshAhead,
+		data:            make(map[string]any),
+		expiry:          make(map[string]int64),
+		failures:        make(map[string]failure),
 	}
 
 	if config.DefaultExpiration != 0 {
 		c.expiration = config.DefaultExpiration
 	}
 
+	if config.SnapshotFile != "" {
+		err = c.LoadFile(config.SnapshotFile)
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("can't load snapshot: %w", err)
+		}
+	}
+
+	if config.CleanupInterval != 0 || config.SnapshotInterval != 0 {
+		c.doneChan = make(chan struct{})
+	}
+
 	if config.CleanupInterval != 0 {
 		c.isJanitorWorks = true
-		c.doneChan = make(chan struct{})
-		go c.janitor(config.CleanupInterval)
+		c.workers.Go(func() { c.janitor(config.CleanupInterval) })
+	}
+
+	if config.SnapshotInterval != 0 {
+		c.workers.Go(func() {
+			c.snapshotter(config.SnapshotFile, config.SnapshotInterval, config.OnSnapshotError)
+		})
 	}
 
 	return c, nil
@@ -102,7 +161,7 @@ func (c *Cache) Has(key string) bool {
 		c.mu.RUnlock()
 
 		if !c.isJanitorWorks {
-			c.Delete(key)
+			c.expire(key)
 		}
 
 		return false
@@ -162,6 +221,7 @@ func (c *Cache) Set(key string, data any, expiration ...time.Duration) bool {
 	}
 
 	c.data[key] = data
+	delete(c.failures, key)
 
 	c.mu.Unlock()
 
@@ -187,7 +247,7 @@ func (c *Cache) Get(key string) any {
 		c.mu.RUnlock()
 
 		if !c.isJanitorWorks {
-			c.Delete(key)
+			c.expire(key)
 		}
 
 		return nil
@@ -239,7 +299,7 @@ func (c *Cache) GetWithExpiration(key string) (any, time.Time) {
 		c.mu.RUnlock()
 
 		if !c.isJanitorWorks {
-			c.Delete(key)
+			c.expire(key)
 		}
 
 		return nil, time.Time{}
@@ -252,6 +312,44 @@ func (c *Cache) GetWithExpiration(key string) (any, time.Time) {
 	return item, time.Unix(0, expiration)
 }
 
+// GetOrLoad returns item from cache or loads it using given loader function and
+// adds it to cache. Concurrent loads of the same key are deduplicated. If error
+// expiration is set, loader errors are cached and returned without calling loader
+// again. If refresh ahead period is set, items which are about to expire are
+// returned immediately and reloaded in background.
+func (c *Cache) GetOrLoad(key string, loader func() (any, error)) (any, error) {
+	switch {
+	case c == nil:
+		return nil, ErrNilCache
+	case loader == nil:
+		return nil, ErrNilLoader
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.RLock()
+
+	item := c.data[key]
+	expiration, ok := c.expiry[key]
+	fail, failed := c.failures[key]
+
+	c.mu.RUnlock()
+
+	if ok && now <= expiration {
+		if c.refreshAhead > 0 && expiration-now <= int64(c.refreshAhead) {
+			c.loads.Go(key, func() (any, error) { return c.load(key, loader) })
+		}
+
+		return item, nil
+	}
+
+	if failed && now <= fail.expiry {
+		return nil, fail.err
+	}
+
+	return c.loads.Do(key, func() (any, error) { return c.load(key, loader) })
+}
+
 // Keys is an iterator over cache keys
 func (c *Cache) Keys(yield func(k string) bool) {
 	if c == nil {
@@ -296,10 +394,10 @@ func (c *Cache) Delete(key string) bool {
 
 	c.mu.Lock()
 
-	delete(c.data, key)
-	delete(c.expiry, key)
+	c.remove(key, cache.REASON_DELETED)
+	delete(c.failures, key)
 
-	c.mu.Unlock()
+	c.unlock()
 
 	return true
 }
@@ -314,6 +412,12 @@ func (c *Cache) Invalidate() bool {
 
 	c.mu.Lock()
 
+	for key, fail := range c.failures {
+		if now >= fail.expiry {
+			delete(c.failures, key)
+		}
+	}
+
 	if len(c.data) == 0 {
 		c.mu.Unlock()
 		return false
@@ -321,12 +425,11 @@ func (c *Cache) Invalidate() bool {
 
 	for key, expiration := range c.expiry {
 		if now >= expiration {
-			delete(c.data, key)
-			delete(c.expiry, key)
+			c.remove(key, cache.REASON_EXPIRED)
 		}
 	}
 
-	c.mu.Unlock()
+	c.unlock()
 
 	return true
 }
@@ -339,23 +442,58 @@ func (c *Cache) Flush() bool {
 
 	c.mu.Lock()
 
+	if c.hasHandlers() {
+		for key := range c.data {
+			c.remove(key, cache.REASON_FLUSHED)
+		}
+	}
+
 	c.data = make(map[string]any)
 	c.expiry = make(map[string]int64)
+	c.failures = make(map[string]failure)
 
-	c.mu.Unlock()
+	c.unlock()
 
 	return true
 }
 
-// Stop stops janitor goroutine
+// OnEvicted sets handler which is called for every item removed from cache
+// (expired, deleted or flushed). Handler is called synchronously after cache
+// lock is released.
+func (c *Cache) OnEvicted(handler func(key string, value any, reason cache.Reason)) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.onEvicted = handler
+	c.mu.Unlock()
+}
+
+// OnExpired sets handler which is called for every expired item removed from
+// cache
+func (c *Cache) OnExpired(handler func(key string, value any)) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.onExpired = handler
+	c.mu.Unlock()
+}
+
+// Stop stops janitor and snapshot goroutines. If periodic snapshots are enabled,
+// Stop saves final snapshot before return.
 func (c *Cache) Stop() {
-	if c == nil || !c.isJanitorWorks || c.doneChan == nil {
+	if c == nil || c.doneChan == nil {
 		return
 	}
 
 	c.stopOnce.Do(func() {
 		close(c.doneChan)
 	})
+
+	c.workers.Wait()
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -370,11 +508,95 @@ func (c Config) Validate() error {
 		return errors.New("cleanup interval is too short (< 1ms)")
 	}
 
+	if c.ErrorExpiration != 0 && c.ErrorExpiration < MIN_EXPIRATION {
+		return errors.New("error expiration is too short (< 1ms)")
+	}
+
+	if c.RefreshAhead < 0 {
+		return errors.New("refresh ahead period can't be less than 0")
+	}
+
+	if c.SnapshotInterval != 0 && c.SnapshotInterval < MIN_CLEANUP_INTERVAL {
+		return errors.New("snapshot interval is too short (< 1ms)")
+	}
+
+	if c.SnapshotInterval != 0 && c.SnapshotFile == "" {
+		return errors.New("snapshot file is not set")
+	}
+
 	return nil
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// load loads item using given loader function and adds it to cache
+func (c *Cache) load(key string, loader func() (any, error)) (any, error) {
+	item, err := loader()
+
+	if err != nil {
+		if c.errorExpiration > 0 {
+			c.mu.Lock()
+			c.failures[key] = failure{err, time.Now().Add(c.errorExpiration).UnixNano()}
+			c.mu.Unlock()
+		}
+
+		return nil, err
+	}
+
+	c.Set(key, item)
+
+	return item, nil
+}
+
+// expire removes item if it is expired
+func (c *Cache) expire(key string) {
+	c.mu.Lock()
+
+	expiration, ok := c.expiry[key]
+
+	if ok && time.Now().UnixNano() > expiration {
+		c.remove(key, cache.REASON_EXPIRED)
+	}
+
+	c.unlock()
+}
+
+// remove removes item from cache and adds it to the queue for eviction handlers.
+// Caller must hold c.mu for writing.
+func (c *Cache) remove(key string, reason cache.Reason) {
+	value, ok := c.data[key]
+
+	delete(c.data, key)
+	delete(c.expiry, key)
+
+	if ok && c.hasHandlers() {
+		c.pending = append(c.pending, removed[string, any]{key, value, reason})
+	}
+}
+
+// hasHandlers returns true if eviction handlers are set
+func (c *Cache) hasHandlers() bool {
+	return c.onEvicted != nil || c.onExpired != nil
+}
+
+// unlock releases lock and passes all removed items to eviction handlers
+func (c *Cache) unlock() {
+	pending, onEvicted, onExpired := c.pending, c.onEvicted, c.onExpired
+	c.pending = nil
+
+	c.mu.Unlock()
+
+	for _, item := range pending {
+		if onExpired != nil && item.reason == cache.REASON_EXPIRED {
+			onExpired(item.key, item.value)
+		}
+
+		if onEvicted != nil {
+			onEvicted(item.key, item.value, item.reason)
+		}
+	}
+}
+
 // janitor is cache cleanup job
 func (c *Cache) janitor(interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -8,10 +8,21 @@ package memory
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/essentialkaos/check"
+
+	"github.com/essentialkaos/ek/v14/cache"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -163,11 +174,21 @@ func (s *CacheSuite) TestNil(c *C) {
 	c.Assert(cache.Invalidate(), Equals, false)
 
 	cache.Stop()
+	cache.OnEvicted(nil)
+	cache.OnExpired(nil)
 
 	item, exp := cache.GetWithExpiration("1")
 	c.Assert(item, Equals, nil)
 	c.Assert(exp.IsZero(), Equals, true)
 
+	c.Assert(cache.Save(io.Discard), Equals, ErrNilCache)
+	c.Assert(cache.Load(strings.NewReader("")), Equals, ErrNilCache)
+	c.Assert(cache.SaveFile("/tmp/cache.snap"), Equals, ErrNilCache)
+	c.Assert(cache.LoadFile("/tmp/cache.snap"), Equals, ErrNilCache)
+
+	_, err := cache.GetOrLoad("1", func() (any, error) { return "TEST", nil })
+	c.Assert(err, Equals, ErrNilCache)
+
 	c.Assert(func() {
 		for range cache.Keys {
 		}
@@ -184,4 +205,841 @@ func (s *CacheSuite) TestConfig(c *C) {
 	_, err = New(Config{DefaultExpiration: time.Minute, CleanupInterval: 1})
 
 	c.Assert(err.Error(), Equals, "invalid configuration: cleanup interval is too short (< 1ms)")
+
+	_, err = New(Config{ErrorExpiration: 1})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: error expiration is too short (< 1ms)")
+
+	_, err = New(Config{RefreshAhead: -1})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: refresh ahead period can't be less than 0")
+
+	_, err = New(Config{SnapshotFile: "/tmp/cache.snap", SnapshotInterval: 1})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: snapshot interval is too short (< 1ms)")
+
+	_, err = New(Config{SnapshotInterval: time.Minute})
+
+	c.Assert(err.Error(), Equals, "invalid configuration: snapshot file is not set")
+}
+
+func (s *CacheSuite) TestGetOrLoad(c *C) {
+	cache, err := New(Config{
+		DefaultExpiration: time.Second / 4,
+		ErrorExpiration:   time.Second / 8,
+	})
+
+	c.Assert(err, IsNil)
+
+	_, err = cache.GetOrLoad("1", nil)
+	c.Assert(err, Equals, ErrNilLoader)
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+
+	loader := func() (any, error) {
+		calls.Add(1)
+		time.Sleep(time.Second / 16)
+		return "TEST", nil
+	}
+
+	for range 10 {
+		wg.Go(func() {
+			v, err := cache.GetOrLoad("1", loader)
+			c.Check(err, IsNil)
+			c.Check(v, Equals, "TEST")
+		})
+	}
+
+	wg.Wait()
+
+	c.Assert(calls.Load(), Equals, int32(1))
+	c.Assert(cache.Get("1"), Equals, "TEST")
+
+	v, err := cache.GetOrLoad("1", loader)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, "TEST")
+	c.Assert(calls.Load(), Equals, int32(1))
+
+	errLoad := errors.New("load error")
+	errLoader := func() (any, error) {
+		calls.Add(1)
+		return nil, errLoad
+	}
+
+	calls.Store(0)
+
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+	c.Assert(calls.Load(), Equals, int32(1))
+	c.Assert(cache.Has("2"), Equals, false)
+
+	time.Sleep(time.Second / 4)
+
+	c.Assert(cache.Invalidate(), Equals, true)
+	c.Assert(cache.failures, HasLen, 0)
+
+	v, err = cache.GetOrLoad("2", loader)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, "TEST")
+
+	_, err = cache.GetOrLoad("3", errLoader)
+	c.Assert(err, Equals, errLoad)
+	c.Assert(cache.Set("3", "ABCD"), Equals, true)
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("4", errLoader)
+	c.Assert(err, Equals, errLoad)
+	cache.Delete("4")
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("4", errLoader)
+	c.Assert(err, Equals, errLoad)
+	cache.Flush()
+	c.Assert(cache.failures, HasLen, 0)
+
+	v, err = cache.GetOrLoad("5", func() (any, error) { panic("ERROR") })
+	c.Assert(v, IsNil)
+	c.Assert(err, ErrorMatches, "function panicked: ERROR")
+}
+
+func (s *CacheSuite) TestGetOrLoadRefresh(c *C) {
+	cache, err := New(Config{
+		DefaultExpiration: time.Second / 4,
+		RefreshAhead:      time.Second / 8,
+	})
+
+	c.Assert(err, IsNil)
+
+	var calls atomic.Int32
+
+	loader := func() (any, error) {
+		return int(calls.Add(1)), nil
+	}
+
+	v, err := cache.GetOrLoad("1", loader)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, 1)
+
+	time.Sleep(time.Second / 6)
+
+	v, err = cache.GetOrLoad("1", loader)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, 1)
+
+	for range 20 {
+		if cache.Get("1") == 2 {
+			break
+		}
+
+		time.Sleep(time.Second / 100)
+	}
+
+	c.Assert(cache.Get("1"), Equals, 2)
+}
+
+func (s *CacheSuite) TestEvictionHandlers(c *C) {
+	mc, err := New(Config{DefaultExpiration: time.Second / 16})
+
+	c.Assert(err, IsNil)
+
+	var evicted []string
+	var expired []string
+
+	mc.OnEvicted(func(key string, value any, reason cache.Reason) {
+		evicted = append(evicted, key+":"+value.(string)+":"+reason.String())
+	})
+
+	mc.OnExpired(func(key string, value any) {
+		expired = append(expired, key)
+	})
+
+	mc.Set("1", "A")
+	mc.Set("2", "B")
+	mc.Set("3", "C", time.Minute)
+	mc.Set("4", "D", time.Minute)
+
+	mc.Delete("3")
+	mc.Delete("5")
+
+	time.Sleep(time.Second / 8)
+
+	c.Assert(mc.Get("1"), IsNil)
+	c.Assert(mc.Invalidate(), Equals, true)
+	c.Assert(mc.Flush(), Equals, true)
+
+	c.Assert(evicted, DeepEquals, []string{
+		"3:C:deleted", "1:A:expired", "2:B:expired", "4:D:flushed",
+	})
+	c.Assert(expired, DeepEquals, []string{"1", "2"})
+
+	mc.OnEvicted(nil)
+	mc.OnExpired(nil)
+	mc.Set("1", "A")
+	mc.Delete("1")
+
+	c.Assert(evicted, HasLen, 4)
+}
+
+func (s *CacheSuite) TestSnapshot(c *C) {
+	mc, err := New(Config{DefaultExpiration: time.Minute})
+
+	c.Assert(err, IsNil)
+
+	mc.Set("1", "TEST")
+	mc.Set("2", 123, time.Second/10)
+	mc.Set("3", []string{"A", "B"}, time.Second/20)
+
+	exp := mc.GetExpiration("1")
+
+	var buf bytes.Buffer
+
+	c.Assert(mc.Save(&buf), IsNil)
+
+	time.Sleep(time.Second / 16)
+
+	mc, err = New(Config{})
+
+	c.Assert(err, IsNil)
+	c.Assert(mc.Load(&buf), IsNil)
+
+	c.Assert(mc.Size(), Equals, 2)
+	c.Assert(mc.Get("1"), Equals, "TEST")
+	c.Assert(mc.Get("2"), Equals, 123)
+	c.Assert(mc.Has("3"), Equals, false)
+	c.Assert(mc.GetExpiration("1").Equal(exp), Equals, true)
+
+	c.Assert(mc.Save(nil), Equals, ErrNilWriter)
+	c.Assert(mc.Load(nil), Equals, ErrNilReader)
+	c.Assert(mc.Load(strings.NewReader("TEST")), ErrorMatches, "can't decode snapshot header: .*")
+
+	buf.Reset()
+	gob.NewEncoder(&buf).Encode(snapshotHeader{Version: 99})
+	c.Assert(mc.Load(&buf), ErrorMatches, `unsupported snapshot version \(99\)`)
+
+	buf.Reset()
+	gob.NewEncoder(&buf).Encode(snapshotHeader{SNAPSHOT_VERSION, 1})
+	c.Assert(mc.Load(&buf), ErrorMatches, "can't decode snapshot item: .*")
+
+	buf.Reset()
+	gob.NewEncoder(&buf).Encode(snapshotHeader{SNAPSHOT_VERSION, math.MaxInt})
+	c.Assert(mc.Load(&buf), ErrorMatches, "can't decode snapshot item: .*")
+
+	buf.Reset()
+	gob.NewEncoder(&buf).Encode(snapshotHeader{SNAPSHOT_VERSION, -1})
+	c.Assert(mc.Load(&buf), ErrorMatches, `invalid number of items in snapshot header \(-1\)`)
+
+	mc.Set("4", struct{ A int }{1})
+	c.Assert(mc.Save(&buf), ErrorMatches, `can't encode item "4": .*`)
+	c.Assert(mc.Save(&errWriter{}), ErrorMatches, "can't encode snapshot header: .*")
+}
+
+func (s *CacheSuite) TestSnapshotFile(c *C) {
+	file := c.MkDir() + "/cache.snap"
+
+	mc, err := New(Config{
+		DefaultExpiration: time.Minute,
+		SnapshotFile:      file,
+		SnapshotInterval:  time.Second / 20,
+	})
+
+	c.Assert(err, IsNil)
+
+	mc.Set("1", "TEST")
+
+	time.Sleep(time.Second / 10)
+
+	_, err = os.Stat(file)
+	c.Assert(err, IsNil)
+
+	mc.Set("2", "ABCD")
+	mc.Stop()
+
+	mc, err = New(Config{SnapshotFile: file})
+
+	c.Assert(err, IsNil)
+	c.Assert(mc.Get("1"), Equals, "TEST")
+	c.Assert(mc.Get("2"), Equals, "ABCD")
+
+	c.Assert(mc.SaveFile("/_unknown_/cache.snap"), ErrorMatches, "can't create snapshot file: .*")
+	c.Assert(mc.LoadFile("/_unknown_/cache.snap"), ErrorMatches, "can't open snapshot file: .*")
+
+	mc.Set("3", struct{ A int }{1})
+	c.Assert(mc.SaveFile(file), NotNil)
+
+	var snapErrs atomic.Int32
+
+	mc, err = New(Config{
+		SnapshotFile:     c.MkDir() + "/cache.snap",
+		SnapshotInterval: time.Second / 20,
+		OnSnapshotError:  func(err error) { snapErrs.Add(1) },
+	})
+
+	c.Assert(err, IsNil)
+
+	mc.Set("1", struct{ A int }{1})
+
+	time.Sleep(time.Second / 8)
+
+	mc.Stop()
+
+	c.Assert(snapErrs.Load() >= 2, Equals, true)
+
+	os.WriteFile(file, []byte("TEST"), 0600)
+
+	_, err = New(Config{SnapshotFile: file})
+	c.Assert(err, ErrorMatches, "can't load snapshot: can't decode snapshot header: .*")
+}
+
+func (s *CacheSuite) TestTypedCache(c *C) {
+	cache, err := NewTyped[string, int](TypedConfig[string]{
+		DefaultExpiration: time.Second / 16,
+		CleanupInterval:   time.Second / 32,
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(cache, NotNil)
+
+	c.Assert(cache.Invalidate(), Equals, false)
+
+	c.Assert(cache.Set("1", 1), Equals, true)
+	c.Assert(cache.Set("2", 2), Equals, true)
+	c.Assert(cache.Set("3", 3, time.Minute), Equals, true)
+	c.Assert(cache.Set("3", 33, time.Minute), Equals, true)
+
+	c.Assert(cache.Size(), Equals, 3)
+	c.Assert(cache.Cost(), Equals, int64(3))
+	c.Assert(cache.Has("2"), Equals, true)
+	c.Assert(cache.Has("4"), Equals, false)
+
+	v, ok := cache.Get("3")
+	c.Assert(v, Equals, 33)
+	c.Assert(ok, Equals, true)
+
+	v, ok = cache.Get("4")
+	c.Assert(v, Equals, 0)
+	c.Assert(ok, Equals, false)
+
+	v, exp := cache.GetWithExpiration("1")
+	c.Assert(v, Equals, 1)
+	c.Assert(exp.IsZero(), Equals, false)
+	c.Assert(cache.GetExpiration("1").IsZero(), Equals, false)
+
+	v, exp = cache.GetWithExpiration("4")
+	c.Assert(v, Equals, 0)
+	c.Assert(exp.IsZero(), Equals, true)
+	c.Assert(cache.GetExpiration("4").IsZero(), Equals, true)
+
+	c.Assert(cache.Stats(), DeepEquals, Stats{Hits: 2, Misses: 2})
+
+	var keys []string
+
+	for k := range cache.Keys {
+		keys = append(keys, k)
+		cache.Get(k)
+	}
+
+	c.Assert(keys, HasLen, 3)
+
+	sum := 0
+
+	for _, v := range cache.All {
+		sum += v
+	}
+
+	c.Assert(sum, Equals, 36)
+
+	for range cache.Keys {
+		break
+	}
+
+	for range cache.All {
+		break
+	}
+
+	c.Assert(cache.Delete("1"), Equals, true)
+	c.Assert(cache.Delete("1"), Equals, false)
+
+	time.Sleep(time.Second / 8)
+
+	c.Assert(cache.Expired(), Equals, 0)
+	c.Assert(cache.Size(), Equals, 1)
+
+	c.Assert(cache.Flush(), Equals, true)
+	c.Assert(cache.Size(), Equals, 0)
+	c.Assert(cache.Cost(), Equals, int64(0))
+
+	cache.Stop()
+	cache.Stop()
+}
+
+func (s *CacheSuite) TestTypedCacheWithoutJanitor(c *C) {
+	cache, err := NewTyped[int, string](TypedConfig[int]{
+		DefaultExpiration: time.Second / 16,
+	})
+
+	c.Assert(err, IsNil)
+
+	cache.Set(1, "A")
+	cache.Set(2, "B")
+	cache.Set(3, "C")
+	cache.Set(4, "D", time.Minute)
+
+	time.Sleep(time.Second / 8)
+
+	var keys []int
+
+	for k := range cache.Keys {
+		keys = append(keys, k)
+	}
+
+	for _, v := range cache.All {
+		c.Assert(v, Equals, "D")
+	}
+
+	c.Assert(keys, DeepEquals, []int{4})
+	c.Assert(cache.Size(), Equals, 4)
+	c.Assert(cache.Expired(), Equals, 3)
+	c.Assert(cache.Has(1), Equals, false)
+
+	_, ok := cache.Get(2)
+	c.Assert(ok, Equals, false)
+
+	_, exp := cache.GetWithExpiration(3)
+	c.Assert(exp.IsZero(), Equals, true)
+
+	c.Assert(cache.Size(), Equals, 1)
+	c.Assert(cache.Invalidate(), Equals, true)
+	c.Assert(cache.Size(), Equals, 1)
+}
+
+func (s *CacheSuite) TestTypedCacheAdapter(c *C) {
+	tc, err := NewTyped[string, int](TypedConfig[string]{
+		DefaultExpiration: time.Second / 16,
+	})
+
+	c.Assert(err, IsNil)
+
+	var ac cache.Cache = AsCache(tc)
+
+	c.Assert(ac.Set("1", 1), Equals, true)
+	c.Assert(ac.Set("2", 2, time.Minute), Equals, true)
+	c.Assert(ac.Set("3", "3"), Equals, false)
+
+	c.Assert(ac.Has("1"), Equals, true)
+	c.Assert(ac.Has("3"), Equals, false)
+	c.Assert(ac.Size(), Equals, 2)
+	c.Assert(ac.Get("1"), Equals, 1)
+	c.Assert(ac.Get("3"), IsNil)
+	c.Assert(ac.GetExpiration("2").IsZero(), Equals, false)
+
+	v, exp := ac.GetWithExpiration("2")
+	c.Assert(v, Equals, 2)
+	c.Assert(exp.IsZero(), Equals, false)
+
+	v, exp = ac.GetWithExpiration("3")
+	c.Assert(v, IsNil)
+	c.Assert(exp.IsZero(), Equals, true)
+
+	time.Sleep(time.Second / 8)
+
+	c.Assert(ac.Expired(), Equals, 1)
+
+	var keys []string
+
+	for k := range ac.Keys {
+		keys = append(keys, k)
+	}
+
+	items := map[string]any{}
+
+	for k, v := range ac.All {
+		items[k] = v
+	}
+
+	for range ac.All {
+		break
+	}
+
+	c.Assert(keys, DeepEquals, []string{"2"})
+	c.Assert(items, DeepEquals, map[string]any{"2": 2})
+
+	c.Assert(ac.Invalidate(), Equals, true)
+	c.Assert(ac.Size(), Equals, 1)
+	c.Assert(ac.Delete("2"), Equals, true)
+	c.Assert(ac.Flush(), Equals, true)
+
+	ac.Stop()
+}
+
+func (s *CacheSuite) TestTypedCacheLimits(c *C) {
+	cache, err := NewTyped[int, string](TypedConfig[int]{MaxEntries: 3})
+
+	c.Assert(err, IsNil)
+
+	cache.Set(1, "A")
+	cache.Set(2, "B")
+	cache.Set(3, "C")
+	cache.Get(1)
+	cache.Set(4, "D")
+
+	c.Assert(cache.Size(), Equals, 3)
+	c.Assert(cache.Has(1), Equals, true)
+	c.Assert(cache.Has(2), Equals, false)
+	c.Assert(cache.Stats().Evictions, Equals, uint64(1))
+
+	cache.Delete(3)
+	cache.Set(5, "E")
+
+	c.Assert(cache.Size(), Equals, 3)
+	c.Assert(cache.Stats().Evictions, Equals, uint64(1))
+
+	cache, err = NewTyped[int, string](TypedConfig[int]{MaxCost: 10})
+
+	c.Assert(err, IsNil)
+
+	c.Assert(cache.SetWithCost(1, "A", 4), Equals, true)
+	c.Assert(cache.SetWithCost(2, "B", 4), Equals, true)
+	c.Assert(cache.SetWithCost(3, "C", 4), Equals, true)
+	c.Assert(cache.Cost(), Equals, int64(8))
+	c.Assert(cache.Has(1), Equals, false)
+	c.Assert(cache.SetWithCost(3, "C", 6), Equals, true)
+	c.Assert(cache.Cost(), Equals, int64(10))
+	c.Assert(cache.SetWithCost(4, "D", 11), Equals, false)
+	c.Assert(cache.SetWithCost(4, "D", -1), Equals, false)
+	c.Assert(cache.SetWithCost(4, "D", 10), Equals, true)
+	c.Assert(cache.Size(), Equals, 1)
+	c.Assert(cache.Cost(), Equals, int64(10))
+}
+
+func (s *CacheSuite) TestTypedCachePolicies(c *C) {
+	lfu, _ := NewTyped[int, int](TypedConfig[int]{MaxEntries: 3, Policy: NewLFU[int]()})
+
+	lfu.Set(1, 1)
+	lfu.Set(2, 2)
+	lfu.Set(3, 3)
+	lfu.Get(1)
+	lfu.Get(1)
+	lfu.Get(2)
+	lfu.Get(3)
+	lfu.Get(3)
+	lfu.Set(4, 4)
+
+	c.Assert(lfu.Has(2), Equals, false)
+	c.Assert(lfu.Has(1), Equals, true)
+	c.Assert(lfu.Has(3), Equals, true)
+
+	lfu.Delete(4)
+	lfu.Delete(1)
+	lfu.Delete(3)
+	lfu.Set(5, 5)
+	lfu.Set(6, 6)
+	lfu.Set(7, 7)
+	lfu.Get(6)
+	lfu.Set(8, 8)
+
+	c.Assert(lfu.Has(5), Equals, false)
+	c.Assert(lfu.Size(), Equals, 3)
+
+	lfu.Flush()
+	c.Assert(lfu.Size(), Equals, 0)
+
+	arc, _ := NewTyped[int, int](TypedConfig[int]{MaxEntries: 3, Policy: NewARC[int](3)})
+
+	for i := range 3 {
+		arc.Set(i, i)
+		arc.Get(i)
+	}
+
+	// Scan of new keys must not evict frequently used keys
+	for i := 10; i < 20; i++ {
+		arc.Set(i, i)
+	}
+
+	c.Assert(arc.Size(), Equals, 3)
+	c.Assert(arc.Has(0) || arc.Has(1) || arc.Has(2), Equals, true)
+
+	// Return of recently evicted key adapts policy
+	arc.Set(10, 10)
+	arc.Set(11, 11)
+	c.Assert(arc.Size(), Equals, 3)
+
+	arc.Delete(10)
+	arc.Flush()
+	c.Assert(arc.Size(), Equals, 0)
+
+	tlfu, _ := NewTyped[int, int](TypedConfig[int]{MaxEntries: 3, Policy: NewTinyLFU[int](3)})
+
+	for i := range 3 {
+		tlfu.Set(i, i)
+
+		for range 5 {
+			tlfu.Get(i)
+		}
+	}
+
+	// Rarely used key must be rejected
+	c.Assert(tlfu.Set(100, 100), Equals, false)
+	c.Assert(tlfu.Has(100), Equals, false)
+	c.Assert(tlfu.Size(), Equals, 3)
+
+	for range 10 {
+		tlfu.Set(100, 100)
+	}
+
+	c.Assert(tlfu.Has(100), Equals, true)
+	c.Assert(tlfu.Size(), Equals, 3)
+
+	tlfu.Delete(100)
+	tlfu.Flush()
+	c.Assert(tlfu.Size(), Equals, 0)
+}
+
+func (s *CacheSuite) TestPolicies(c *C) {
+	lru := NewLRU[string]()
+
+	_, ok := lru.Victim("")
+	c.Assert(ok, Equals, false)
+
+	lru.Add("A")
+	lru.Add("B")
+	lru.Add("A")
+	lru.Access("C")
+
+	k, ok := lru.Victim("")
+	c.Assert(k, Equals, "B")
+	c.Assert(ok, Equals, true)
+
+	lfu := NewLFU[string]()
+
+	_, ok = lfu.Victim("")
+	c.Assert(ok, Equals, false)
+
+	lfu.Add("A")
+	lfu.Add("A")
+	lfu.Add("B")
+	lfu.Access("C")
+	lfu.Remove("C")
+
+	k, _ = lfu.Victim("")
+	c.Assert(k, Equals, "B")
+
+	arc := NewARC[string](0)
+
+	_, ok = arc.Victim("")
+	c.Assert(ok, Equals, false)
+
+	arc.Add("A")
+	arc.Add("A")
+	arc.Access("A")
+	arc.Add("B")
+	arc.Access("B")
+	arc.Access("C")
+
+	k, _ = arc.Victim("")
+	c.Assert(k, Equals, "A")
+
+	arc.Add("A")
+	k, _ = arc.Victim("")
+	c.Assert(k, Equals, "B")
+
+	tlfu := NewTinyLFU[string](0)
+
+	_, ok = tlfu.Victim("")
+	c.Assert(ok, Equals, false)
+
+	sketch := newCountMinSketch(4)
+
+	for range 100 {
+		sketch.increment(1)
+	}
+
+	c.Assert(sketch.estimate(1) < 100, Equals, true)
+	c.Assert(sketch.estimate(1) > 0, Equals, true)
+
+	sketch.reset()
+	c.Assert(sketch.estimate(1), Equals, uint8(0))
+}
+
+func (s *CacheSuite) TestTypedNil(c *C) {
+	var cache *TypedCache[string, string]
+
+	c.Assert(cache.Set("1", "TEST"), Equals, false)
+	c.Assert(cache.SetWithCost("1", "TEST", 1), Equals, false)
+	c.Assert(cache.Delete("1"), Equals, false)
+	c.Assert(cache.Flush(), Equals, false)
+	c.Assert(cache.Size(), Equals, 0)
+	c.Assert(cache.Cost(), Equals, int64(0))
+	c.Assert(cache.Expired(), Equals, 0)
+	c.Assert(cache.Has("1"), Equals, false)
+	c.Assert(cache.GetExpiration("1").IsZero(), Equals, true)
+	c.Assert(cache.Invalidate(), Equals, false)
+	c.Assert(cache.Stats(), DeepEquals, Stats{})
+
+	cache.Stop()
+	cache.OnEvicted(nil)
+	cache.OnExpired(nil)
+
+	v, ok := cache.Get("1")
+	c.Assert(v, Equals, "")
+	c.Assert(ok, Equals, false)
+
+	v, exp := cache.GetWithExpiration("1")
+	c.Assert(v, Equals, "")
+	c.Assert(exp.IsZero(), Equals, true)
+
+	_, err := cache.GetOrLoad("1", func() (string, error) { return "TEST", nil })
+	c.Assert(err, Equals, ErrNilCache)
+
+	c.Assert(func() {
+		for range cache.Keys {
+		}
+		for range cache.All {
+		}
+	}, NotPanics)
+}
+
+func (s *CacheSuite) TestTypedConfig(c *C) {
+	_, err := NewTyped[string, string](TypedConfig[string]{DefaultExpiration: 1})
+	c.Assert(err, ErrorMatches, `invalid configuration: expiration is too short \(< 1ms\)`)
+
+	_, err = NewTyped[string, string](TypedConfig[string]{RefreshAhead: -1})
+	c.Assert(err, ErrorMatches, `invalid configuration: refresh ahead period can't be less than 0`)
+
+	_, err = NewTyped[string, string](TypedConfig[string]{MaxEntries: -1})
+	c.Assert(err, ErrorMatches, `invalid configuration: max entries can't be less than 0`)
+
+	_, err = NewTyped[string, string](TypedConfig[string]{MaxCost: -1})
+	c.Assert(err, ErrorMatches, `invalid configuration: max cost can't be less than 0`)
+}
+
+func (s *CacheSuite) TestTypedGetOrLoad(c *C) {
+	cache, err := NewTyped[string, int](TypedConfig[string]{
+		DefaultExpiration: time.Second / 4,
+		ErrorExpiration:   time.Second / 8,
+		RefreshAhead:      time.Second / 8,
+	})
+
+	c.Assert(err, IsNil)
+
+	_, err = cache.GetOrLoad("1", nil)
+	c.Assert(err, Equals, ErrNilLoader)
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+
+	loader := func() (int, error) {
+		n := calls.Add(1)
+		time.Sleep(time.Second / 32)
+		return int(n), nil
+	}
+
+	for range 10 {
+		wg.Go(func() {
+			v, err := cache.GetOrLoad("1", loader)
+			c.Check(err, IsNil)
+			c.Check(v, Equals, 1)
+		})
+	}
+
+	wg.Wait()
+
+	c.Assert(calls.Load(), Equals, int32(1))
+
+	time.Sleep(time.Second / 6)
+
+	v, err := cache.GetOrLoad("1", loader)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, 1)
+
+	for range 20 {
+		if v, _ := cache.Get("1"); v == 2 {
+			break
+		}
+
+		time.Sleep(time.Second / 100)
+	}
+
+	v, _ = cache.Get("1")
+	c.Assert(v, Equals, 2)
+
+	errLoad := errors.New("load error")
+	errLoader := func() (int, error) { return 0, errLoad }
+
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+	_, err = cache.GetOrLoad("2", loader)
+	c.Assert(err, Equals, errLoad)
+
+	c.Assert(cache.Delete("2"), Equals, false)
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+
+	time.Sleep(time.Second / 6)
+
+	c.Assert(cache.Invalidate(), Equals, true)
+	c.Assert(cache.failures, HasLen, 0)
+
+	_, err = cache.GetOrLoad("2", errLoader)
+	c.Assert(err, Equals, errLoad)
+	cache.Flush()
+	c.Assert(cache.failures, HasLen, 0)
+}
+
+func (s *CacheSuite) TestTypedEvictionHandlers(c *C) {
+	tc, err := NewTyped[string, int](TypedConfig[string]{
+		DefaultExpiration: time.Second / 16,
+		MaxEntries:        2,
+	})
+
+	c.Assert(err, IsNil)
+
+	var evicted []removed[string, int]
+	var expired []string
+
+	tc.OnEvicted(func(key string, value int, reason cache.Reason) {
+		c.Assert(tc.Has(key), Equals, false)
+		evicted = append(evicted, removed[string, int]{key, value, reason})
+	})
+
+	tc.OnExpired(func(key string, value int) {
+		expired = append(expired, key)
+	})
+
+	tc.Set("1", 1)
+	tc.Set("2", 2, time.Minute)
+	tc.Set("3", 3, time.Minute)
+
+	c.Assert(tc.Delete("2"), Equals, true)
+
+	time.Sleep(time.Second / 8)
+
+	tc.Set("4", 4)
+
+	time.Sleep(time.Second / 8)
+
+	_, ok := tc.Get("4")
+	c.Assert(ok, Equals, false)
+
+	tc.Flush()
+
+	c.Assert(evicted, DeepEquals, []removed[string, int]{
+		{"1", 1, cache.REASON_CAPACITY},
+		{"2", 2, cache.REASON_DELETED},
+		{"4", 4, cache.REASON_EXPIRED},
+		{"3", 3, cache.REASON_FLUSHED},
+	})
+	c.Assert(expired, DeepEquals, []string{"4"})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type errWriter struct{}
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
 }
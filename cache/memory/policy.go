@@ -0,0 +1,526 @@
+package memory
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"container/list"
+	"hash/maphash"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Policy is eviction policy used by [TypedCache] when size limit is reached.
+// Policy methods are always called under cache lock.
+type Policy[K comparable] interface {
+	// Add registers new key
+	Add(key K)
+
+	// Access registers access to key
+	Access(key K)
+
+	// Remove unregisters key removed from cache (deleted or expired)
+	Remove(key K)
+
+	// Victim chooses key which must be evicted to make room for the candidate and
+	// unregisters it. Policy may return the candidate itself to reject it.
+	Victim(candidate K) (K, bool)
+
+	// Reset removes all keys
+	Reset()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LRU is least recently used eviction policy
+type LRU[K comparable] struct {
+	items map[K]*list.Element
+	order *list.List
+}
+
+// LFU is least frequently used eviction policy. Among keys with the same
+// frequency, the least recently used key is evicted.
+type LFU[K comparable] struct {
+	items   map[K]*list.Element
+	freqs   map[int]*list.List
+	minFreq int
+}
+
+// ARC is adaptive replacement cache eviction policy
+type ARC[K comparable] struct {
+	capacity int
+	p        int
+	t1, t2   *LRU[K]
+	b1, b2   *LRU[K]
+}
+
+// TinyLFU is eviction policy with LRU eviction and frequency-based admission.
+// New key is admitted only if it was accessed more often than the key chosen
+// for eviction.
+type TinyLFU[K comparable] struct {
+	lru    *LRU[K]
+	sketch *countMinSketch
+	seed   maphash.Seed
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// lfuEntry is LFU policy entry
+type lfuEntry[K comparable] struct {
+	key  K
+	freq int
+}
+
+// countMinSketch is a probabilistic frequency counter with periodic aging
+type countMinSketch struct {
+	rows      [4][]uint8
+	mask      uint64
+	additions int
+	resetAt   int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewLRU creates new LRU eviction policy
+func NewLRU[K comparable]() *LRU[K] {
+	return &LRU[K]{items: make(map[K]*list.Element), order: list.New()}
+}
+
+// NewLFU creates new LFU eviction policy
+func NewLFU[K comparable]() *LFU[K] {
+	return &LFU[K]{
+		items: make(map[K]*list.Element),
+		freqs: make(map[int]*list.List),
+	}
+}
+
+// NewARC creates new ARC eviction policy for cache with given maximum number
+// of entries
+func NewARC[K comparable](capacity int) *ARC[K] {
+	return &ARC[K]{
+		capacity: max(capacity, 1),
+		t1:       NewLRU[K](),
+		t2:       NewLRU[K](),
+		b1:       NewLRU[K](),
+		b2:       NewLRU[K](),
+	}
+}
+
+// NewTinyLFU creates new TinyLFU eviction policy for cache with given maximum
+// number of entries
+func NewTinyLFU[K comparable](capacity int) *TinyLFU[K] {
+	return &TinyLFU[K]{
+		lru:    NewLRU[K](),
+		sketch: newCountMinSketch(max(capacity, 16)),
+		seed:   maphash.MakeSeed(),
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Add registers new key
+func (p *LRU[K]) Add(key K) {
+	if e, ok := p.items[key]; ok {
+		p.order.MoveToFront(e)
+		return
+	}
+
+	p.items[key] = p.order.PushFront(key)
+}
+
+// Access registers access to key
+func (p *LRU[K]) Access(key K) {
+	if e, ok := p.items[key]; ok {
+		p.order.MoveToFront(e)
+	}
+}
+
+// Remove unregisters key
+func (p *LRU[K]) Remove(key K) {
+	if e, ok := p.items[key]; ok {
+		p.order.Remove(e)
+		delete(p.items, key)
+	}
+}
+
+// Victim chooses least recently used key
+func (p *LRU[K]) Victim(candidate K) (K, bool) {
+	e := p.order.Back()
+
+	if e == nil {
+		var zero K
+		return zero, false
+	}
+
+	key := e.Value.(K)
+	p.Remove(key)
+
+	return key, true
+}
+
+// Reset removes all keys
+func (p *LRU[K]) Reset() {
+	clear(p.items)
+	p.order.Init()
+}
+
+// has returns true if policy contains given key
+func (p *LRU[K]) has(key K) bool {
+	_, ok := p.items[key]
+	return ok
+}
+
+// size returns number of keys
+func (p *LRU[K]) size() int {
+	return len(p.items)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Add registers new key
+func (p *LFU[K]) Add(key K) {
+	if _, ok := p.items[key]; ok {
+		p.Access(key)
+		return
+	}
+
+	p.items[key] = p.getFreqList(1).PushFront(&lfuEntry[K]{key, 1})
+	p.minFreq = 1
+}
+
+// Access registers access to key
+func (p *LFU[K]) Access(key K) {
+	e, ok := p.items[key]
+
+	if !ok {
+		return
+	}
+
+	entry := e.Value.(*lfuEntry[K])
+	p.removeFromFreqList(e, entry.freq)
+
+	if entry.freq == p.minFreq && p.freqs[entry.freq] == nil {
+		p.minFreq++
+	}
+
+	entry.freq++
+	p.items[key] = p.getFreqList(entry.freq).PushFront(entry)
+}
+
+// Remove unregisters key
+func (p *LFU[K]) Remove(key K) {
+	e, ok := p.items[key]
+
+	if !ok {
+		return
+	}
+
+	p.removeFromFreqList(e, e.Value.(*lfuEntry[K]).freq)
+	delete(p.items, key)
+}
+
+// Victim chooses least frequently used key. Candidate is chosen only if it is
+// the only key.
+func (p *LFU[K]) Victim(candidate K) (K, bool) {
+	var zero K
+
+	if len(p.items) == 0 {
+		return zero, false
+	}
+
+	// minFreq may be outdated after removal of keys
+	if p.freqs[p.minFreq] == nil {
+		p.minFreq = p.getNextFreq(0)
+	}
+
+	e := p.freqs[p.minFreq].Back()
+
+	if e.Value.(*lfuEntry[K]).key == candidate && len(p.items) > 1 {
+		if e.Prev() != nil {
+			e = e.Prev()
+		} else {
+			e = p.freqs[p.getNextFreq(p.minFreq)].Back()
+		}
+	}
+
+	key := e.Value.(*lfuEntry[K]).key
+	p.Remove(key)
+
+	return key, true
+}
+
+// Reset removes all keys
+func (p *LFU[K]) Reset() {
+	clear(p.items)
+	clear(p.freqs)
+	p.minFreq = 0
+}
+
+// getFreqList returns list for given frequency
+func (p *LFU[K]) getFreqList(freq int) *list.List {
+	l := p.freqs[freq]
+
+	if l == nil {
+		l = list.New()
+		p.freqs[freq] = l
+	}
+
+	return l
+}
+
+// getNextFreq returns the smallest frequency greater than given one
+func (p *LFU[K]) getNextFreq(freq int) int {
+	result := 0
+
+	for f := range p.freqs {
+		if f > freq && (result == 0 || f < result) {
+			result = f
+		}
+	}
+
+	return result
+}
+
+// removeFromFreqList removes element from list with given frequency
+func (p *LFU[K]) removeFromFreqList(e *list.Element, freq int) {
+	l := p.freqs[freq]
+	l.Remove(e)
+
+	if l.Len() == 0 {
+		delete(p.freqs, freq)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Add registers new key
+func (p *ARC[K]) Add(key K) {
+	switch {
+	case p.t1.has(key):
+		p.t1.Remove(key)
+		p.t2.Add(key)
+
+	case p.t2.has(key):
+		p.t2.Access(key)
+
+	case p.b1.has(key):
+		// Key was recently evicted from T1, so recency part must grow
+		p.p = min(p.capacity, p.p+max(p.b2.size()/p.b1.size(), 1))
+		p.b1.Remove(key)
+		p.t2.Add(key)
+
+	case p.b2.has(key):
+		// Key was recently evicted from T2, so frequency part must grow
+		p.p = max(0, p.p-max(p.b1.size()/p.b2.size(), 1))
+		p.b2.Remove(key)
+		p.t2.Add(key)
+
+	default:
+		p.t1.Add(key)
+	}
+
+	p.trimGhosts()
+}
+
+// Access registers access to key
+func (p *ARC[K]) Access(key K) {
+	switch {
+	case p.t1.has(key):
+		p.t1.Remove(key)
+		p.t2.Add(key)
+	case p.t2.has(key):
+		p.t2.Access(key)
+	}
+}
+
+// Remove unregisters key
+func (p *ARC[K]) Remove(key K) {
+	p.t1.Remove(key)
+	p.t2.Remove(key)
+}
+
+// Victim chooses key using ARC replacement rule and moves it to ghost list
+func (p *ARC[K]) Victim(candidate K) (K, bool) {
+	var key K
+	var ok bool
+
+	t1Size := p.t1.size()
+
+	if p.t1.has(candidate) {
+		// Candidate itself must not be evicted from T1 before other keys
+		t1Size--
+	}
+
+	if t1Size > 0 && (t1Size > p.p || p.t2.size() == 0) {
+		key, ok = p.t1.Victim(candidate)
+
+		if ok {
+			p.b1.Add(key)
+		}
+	} else {
+		key, ok = p.t2.Victim(candidate)
+
+		if ok {
+			p.b2.Add(key)
+		} else {
+			key, ok = p.t1.Victim(candidate)
+		}
+	}
+
+	p.trimGhosts()
+
+	return key, ok
+}
+
+// Reset removes all keys
+func (p *ARC[K]) Reset() {
+	p.p = 0
+	p.t1.Reset()
+	p.t2.Reset()
+	p.b1.Reset()
+	p.b2.Reset()
+}
+
+// trimGhosts limits size of ghost lists
+func (p *ARC[K]) trimGhosts() {
+	for p.b1.size() > p.capacity {
+		p.b1.Victim(*new(K))
+	}
+
+	for p.b2.size() > p.capacity {
+		p.b2.Victim(*new(K))
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Add registers new key
+func (p *TinyLFU[K]) Add(key K) {
+	p.sketch.increment(p.hash(key))
+	p.lru.Add(key)
+}
+
+// Access registers access to key
+func (p *TinyLFU[K]) Access(key K) {
+	p.sketch.increment(p.hash(key))
+	p.lru.Access(key)
+}
+
+// Remove unregisters key
+func (p *TinyLFU[K]) Remove(key K) {
+	p.lru.Remove(key)
+}
+
+// Victim chooses least recently used key if candidate is accessed more often
+// than it, otherwise candidate is rejected
+func (p *TinyLFU[K]) Victim(candidate K) (K, bool) {
+	e := p.lru.order.Back()
+
+	if e == nil {
+		var zero K
+		return zero, false
+	}
+
+	victim := e.Value.(K)
+
+	if victim != candidate && p.lru.has(candidate) &&
+		p.sketch.estimate(p.hash(candidate)) < p.sketch.estimate(p.hash(victim)) {
+		p.lru.Remove(candidate)
+		return candidate, true
+	}
+
+	p.lru.Remove(victim)
+
+	return victim, true
+}
+
+// Reset removes all keys
+func (p *TinyLFU[K]) Reset() {
+	p.lru.Reset()
+	p.sketch.reset()
+}
+
+// hash returns hash of the key
+func (p *TinyLFU[K]) hash(key K) uint64 {
+	return maphash.Comparable(p.seed, key)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newCountMinSketch creates new count-min sketch for given number of keys
+func newCountMinSketch(size int) *countMinSketch {
+	width := 1
+
+	for width < size {
+		width <<= 1
+	}
+
+	s := &countMinSketch{mask: uint64(width - 1), resetAt: size * 10}
+
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+
+	return s
+}
+
+// increment increments counters for given hash
+func (s *countMinSketch) increment(hash uint64) {
+	for i := range s.rows {
+		idx := s.index(hash, i)
+
+		if s.rows[i][idx] < 255 {
+			s.rows[i][idx]++
+		}
+	}
+
+	s.additions++
+
+	if s.additions >= s.resetAt {
+		s.age()
+	}
+}
+
+// estimate returns estimated frequency for given hash
+func (s *countMinSketch) estimate(hash uint64) uint8 {
+	result := uint8(255)
+
+	for i := range s.rows {
+		result = min(result, s.rows[i][s.index(hash, i)])
+	}
+
+	return result
+}
+
+// age halves all counters
+func (s *countMinSketch) age() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+
+	s.additions /= 2
+}
+
+// reset resets all counters
+func (s *countMinSketch) reset() {
+	for i := range s.rows {
+		clear(s.rows[i])
+	}
+
+	s.additions = 0
+}
+
+// index returns counter index in given row
+func (s *countMinSketch) index(hash uint64, row int) uint64 {
+	h := hash + uint64(row+1)*0x9E3779B97F4A7C15
+	h = (h ^ (h >> 30)) * 0xBF58476D1CE4E5B9
+	h = (h ^ (h >> 27)) * 0x94D049BB133111EB
+
+	return (h ^ (h >> 31)) & s.mask
+}
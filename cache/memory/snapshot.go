@@ -0,0 +1,250 @@
+package memory
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SNAPSHOT_VERSION is current version of snapshot format
+const SNAPSHOT_VERSION = 1
+
+// _MAX_PREALLOC_ITEMS is maximum number of items preallocated while loading
+// snapshot, because number of items in header can't be trusted
+const _MAX_PREALLOC_ITEMS = 4096
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// snapshotHeader is snapshot header
+type snapshotHeader struct {
+	Version int
+	Items   int
+}
+
+// snapshotItem is snapshot item
+type snapshotItem struct {
+	Key    string
+	Value  any
+	Expiry int64
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrNilWriter is returned if writer for snapshot is nil
+	ErrNilWriter = errors.New("writer is nil")
+
+	// ErrNilReader is returned if reader for snapshot is nil
+	ErrNilReader = errors.New("reader is nil")
+
+	// ErrUnsupportedSnapshot is returned if snapshot has unsupported version
+	ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Save writes all non-expired items with their expiration dates to given writer.
+// Items are encoded using GOB, so custom types stored in cache must be registered
+// using gob.Register.
+func (c *Cache) Save(w io.Writer) error {
+	switch {
+	case c == nil:
+		return ErrNilCache
+	case w == nil:
+		return ErrNilWriter
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.RLock()
+
+	items := make([]snapshotItem, 0, len(c.data))
+
+	for key, value := range c.data {
+		if now <= c.expiry[key] {
+			items = append(items, snapshotItem{key, value, c.expiry[key]})
+		}
+	}
+
+	c.mu.RUnlock()
+
+	enc := gob.NewEncoder(w)
+
+	err := enc.Encode(snapshotHeader{SNAPSHOT_VERSION, len(items)})
+
+	if err != nil {
+		return fmt.Errorf("can't encode snapshot header: %w", err)
+	}
+
+	for _, item := range items {
+		err = enc.Encode(item)
+
+		if err != nil {
+			return fmt.Errorf("can't encode item %q: %w", item.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// Load reads items from given reader and adds them to cache. Items which are
+// already expired are skipped.
+func (c *Cache) Load(r io.Reader) error {
+	switch {
+	case c == nil:
+		return ErrNilCache
+	case r == nil:
+		return ErrNilReader
+	}
+
+	var header snapshotHeader
+
+	dec := gob.NewDecoder(r)
+	err := dec.Decode(&header)
+
+	if err != nil {
+		return fmt.Errorf("can't decode snapshot header: %w", err)
+	}
+
+	switch {
+	case header.Version != SNAPSHOT_VERSION:
+		return fmt.Errorf("%w (%d)", ErrUnsupportedSnapshot, header.Version)
+	case header.Items < 0:
+		return fmt.Errorf("invalid number of items in snapshot header (%d)", header.Items)
+	}
+
+	items := make([]snapshotItem, 0, min(header.Items, _MAX_PREALLOC_ITEMS))
+
+	for range header.Items {
+		var item snapshotItem
+
+		err = dec.Decode(&item)
+
+		if err != nil {
+			return fmt.Errorf("can't decode snapshot item: %w", err)
+		}
+
+		items = append(items, item)
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.Lock()
+
+	for _, item := range items {
+		if now > item.Expiry || item.Value == nil {
+			continue
+		}
+
+		c.data[item.Key] = item.Value
+		c.expiry[item.Key] = item.Expiry
+		delete(c.failures, item.Key)
+	}
+
+	c.mu.Unlock()
+
+	return nil
+}
+
+// SaveFile saves snapshot of cache to given file. Snapshot is written to
+// temporary file which is renamed afterwards, so the file always contains
+// complete snapshot.
+func (c *Cache) SaveFile(file string) error {
+	if c == nil {
+		return ErrNilCache
+	}
+
+	tmpFile := filepath.Join(
+		filepath.Dir(file),
+		fmt.Sprintf(".%s-%x", filepath.Base(file), rand.Uint64()),
+	)
+
+	fd, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return fmt.Errorf("can't create snapshot file: %w", err)
+	}
+
+	err = c.Save(fd)
+
+	if err == nil {
+		err = fd.Sync()
+	}
+
+	closeErr := fd.Close()
+
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("can't close snapshot file: %w", closeErr)
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile, file)
+	}
+
+	if err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+
+	return nil
+}
+
+// LoadFile loads snapshot of cache from given file
+func (c *Cache) LoadFile(file string) error {
+	if c == nil {
+		return ErrNilCache
+	}
+
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return fmt.Errorf("can't open snapshot file: %w", err)
+	}
+
+	defer fd.Close()
+
+	return c.Load(fd)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// snapshotter is periodic snapshot job. Snapshot errors are passed to given
+// error handler.
+func (c *Cache) snapshotter(file string, interval time.Duration, onError func(err error)) {
+	ticker := time.NewTicker(interval)
+
+	save := func() {
+		err := c.SaveFile(file)
+
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+
+MAIN:
+	for {
+		select {
+		case <-ticker.C:
+			save()
+		case <-c.doneChan:
+			break MAIN
+		}
+	}
+
+	ticker.Stop()
+	save()
+}
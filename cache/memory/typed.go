@@ -0,0 +1,740 @@
+package memory
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/cache"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TypedCache is generic in-memory cache instance with optional size limits
+type TypedCache[K comparable, V any] struct {
+	items           map[K]*typedItem[V]
+	failures        map[K]failure
+	loads           cache.Group[K, V]
+	policy          Policy[K]
+	mu              sync.Mutex
+	expiration      cache.Duration
+	errorExpiration cache.Duration
+	refreshAhead    cache.Duration
+	onEvicted       func(key K, value V, reason cache.Reason)
+	onExpired       func(key K, value V)
+	pending         []removed[K, V]
+	maxEntries      int
+	maxCost         int64
+	cost            int64
+	stats           Stats
+	doneChan        chan struct{}
+	stopOnce        sync.Once
+	isJanitorWorks  bool
+}
+
+// TypedConfig is generic cache configuration
+type TypedConfig[K comparable] struct {
+	DefaultExpiration cache.Duration
+	CleanupInterval   cache.Duration
+	ErrorExpiration   cache.Duration // Expiration of loader errors (0 = errors are not cached)
+	RefreshAhead      cache.Duration // Period before expiration when item is refreshed in background (0 = disabled)
+	MaxEntries        int            // Maximum number of items (0 = unlimited)
+	MaxCost           int64          // Maximum total cost of items (0 = unlimited)
+	Policy            Policy[K]      // Eviction policy (LRU by default)
+}
+
+// Stats contains cache statistics
+type Stats struct {
+	Hits      uint64 // Number of successful lookups
+	Misses    uint64 // Number of failed lookups
+	Evictions uint64 // Number of items evicted due to size limits
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// typedItem is generic cache item
+type typedItem[V any] struct {
+	value  V
+	expiry int64
+	cost   int64
+}
+
+// cacheAdapter is adapter for using typed cache as cache.Cache
+type cacheAdapter[V any] struct {
+	c *TypedCache[string, V]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// validate adapter interface
+var _ cache.Cache = (*cacheAdapter[any])(nil)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewTyped creates new generic cache instance
+func NewTyped[K comparable, V any](config TypedConfig[K]) (*TypedCache[K, V], error) {
+	err := config.Validate()
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	c := &TypedCache[K, V]{
+		items:           make(map[K]*typedItem[V]),
+		failures:        make(map[K]failure),
+		policy:          config.Policy,
+		expiration:      DEFAULT_EXPIRATION,
+		errorExpiration: config.ErrorExpiration,
+		refreshAhead:    config.RefreshAhead,
+		maxEntries:      config.MaxEntries,
+		maxCost:         config.MaxCost,
+	}
+
+	if c.policy == nil {
+		c.policy = NewLRU[K]()
+	}
+
+	if config.DefaultExpiration != 0 {
+		c.expiration = config.DefaultExpiration
+	}
+
+	if config.CleanupInterval != 0 {
+		c.isJanitorWorks = true
+		c.doneChan = make(chan struct{})
+		go c.janitor(config.CleanupInterval)
+	}
+
+	return c, nil
+}
+
+// AsCache returns adapter which allows to use typed cache with string keys
+// as cache.Cache. Values with type other than V are rejected by Set.
+func AsCache[V any](c *TypedCache[string, V]) cache.Cache {
+	return &cacheAdapter[V]{c}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Has returns true if cache contains data for given key
+func (c *TypedCache[K, V]) Has(key K) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	return c.getItem(key, time.Now().UnixNano(), false) != nil
+}
+
+// Size returns number of items in cache
+func (c *TypedCache[K, V]) Size() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Cost returns total cost of items in cache
+func (c *TypedCache[K, V]) Cost() int64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cost
+}
+
+// Expired returns number of expired items in cache
+func (c *TypedCache[K, V]) Expired() int {
+	if c == nil {
+		return 0
+	}
+
+	items := 0
+	now := time.Now().UnixNano()
+
+	c.mu.Lock()
+
+	for _, item := range c.items {
+		if now > item.expiry {
+			items++
+		}
+	}
+
+	c.mu.Unlock()
+
+	return items
+}
+
+// Set adds or updates item in cache. It returns false if item was rejected by
+// eviction policy.
+func (c *TypedCache[K, V]) Set(key K, value V, expiration ...time.Duration) bool {
+	return c.SetWithCost(key, value, 1, expiration...)
+}
+
+// SetWithCost adds or updates item with given cost in cache. It returns false
+// if item was rejected by eviction policy.
+func (c *TypedCache[K, V]) SetWithCost(key K, value V, cost int64, expiration ...time.Duration) bool {
+	if c == nil || cost < 0 || (c.maxCost > 0 && cost > c.maxCost) {
+		return false
+	}
+
+	ttl := c.expiration
+
+	if len(expiration) > 0 && expiration[0] >= MIN_EXPIRATION {
+		ttl = expiration[0]
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	item := c.items[key]
+
+	if item != nil {
+		c.cost += cost - item.cost
+		item.value, item.cost = value, cost
+		c.policy.Access(key)
+	} else {
+		item = &typedItem[V]{value: value, cost: cost}
+		c.items[key] = item
+		c.cost += cost
+		c.policy.Add(key)
+	}
+
+	item.expiry = time.Now().Add(ttl).UnixNano()
+	delete(c.failures, key)
+
+	return c.evict(key)
+}
+
+// Get returns item from cache
+func (c *TypedCache[K, V]) Get(key K) (V, bool) {
+	var zero V
+
+	if c == nil {
+		return zero, false
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	item := c.getItem(key, time.Now().UnixNano(), true)
+
+	if item == nil {
+		return zero, false
+	}
+
+	return item.value, true
+}
+
+// GetExpiration returns item expiration date
+func (c *TypedCache[K, V]) GetExpiration(key K) time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.items[key]
+
+	if item == nil {
+		return time.Time{}
+	}
+
+	return time.Unix(0, item.expiry)
+}
+
+// GetWithExpiration returns item from cache and expiration date. If there is
+// no item in cache, expiration date is zero.
+func (c *TypedCache[K, V]) GetWithExpiration(key K) (V, time.Time) {
+	var zero V
+
+	if c == nil {
+		return zero, time.Time{}
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	item := c.getItem(key, time.Now().UnixNano(), true)
+
+	if item == nil {
+		return zero, time.Time{}
+	}
+
+	return item.value, time.Unix(0, item.expiry)
+}
+
+// GetOrLoad returns item from cache or loads it using given loader function and
+// adds it to cache. Concurrent loads of the same key are deduplicated. If error
+// expiration is set, loader errors are cached and returned without calling loader
+// again. If refresh ahead period is set, items which are about to expire are
+// returned immediately and reloaded in background.
+func (c *TypedCache[K, V]) GetOrLoad(key K, loader func() (V, error)) (V, error) {
+	var zero V
+
+	switch {
+	case c == nil:
+		return zero, ErrNilCache
+	case loader == nil:
+		return zero, ErrNilLoader
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.Lock()
+
+	item := c.getItem(key, now, true)
+	fail, failed := c.failures[key]
+
+	if item != nil {
+		value, expiry := item.value, item.expiry
+		c.unlock()
+
+		if c.refreshAhead > 0 && expiry-now <= int64(c.refreshAhead) {
+			c.loads.Go(key, func() (V, error) { return c.load(key, loader) })
+		}
+
+		return value, nil
+	}
+
+	c.unlock()
+
+	if failed && now <= fail.expiry {
+		return zero, fail.err
+	}
+
+	return c.loads.Do(key, func() (V, error) { return c.load(key, loader) })
+}
+
+// Stats returns cache statistics
+func (c *TypedCache[K, V]) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// Keys is an iterator over keys of non-expired cache items
+func (c *TypedCache[K, V]) Keys(yield func(k K) bool) {
+	for k := range c.All {
+		if !yield(k) {
+			return
+		}
+	}
+}
+
+// All is an iterator over non-expired cache items. Iterator works with snapshot
+// of cache data, so cache can be modified during iteration.
+func (c *TypedCache[K, V]) All(yield func(k K, v V) bool) {
+	if c == nil {
+		return
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.Lock()
+
+	keys := make([]K, 0, len(c.items))
+	values := make([]V, 0, len(c.items))
+
+	for k, item := range c.items {
+		if now > item.expiry {
+			continue
+		}
+
+		keys = append(keys, k)
+		values = append(values, item.value)
+	}
+
+	c.mu.Unlock()
+
+	for i, k := range keys {
+		if !yield(k, values[i]) {
+			return
+		}
+	}
+}
+
+// Delete removes item from cache
+func (c *TypedCache[K, V]) Delete(key K) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	delete(c.failures, key)
+
+	return c.remove(key, cache.REASON_DELETED)
+}
+
+// Invalidate deletes all expired records
+func (c *TypedCache[K, V]) Invalidate() bool {
+	if c == nil {
+		return false
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	for key, fail := range c.failures {
+		if now >= fail.expiry {
+			delete(c.failures, key)
+		}
+	}
+
+	if len(c.items) == 0 {
+		return false
+	}
+
+	for key, item := range c.items {
+		if now >= item.expiry {
+			c.remove(key, cache.REASON_EXPIRED)
+		}
+	}
+
+	return true
+}
+
+// Flush removes all data from cache
+func (c *TypedCache[K, V]) Flush() bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+
+	if c.hasHandlers() {
+		for key, item := range c.items {
+			c.pending = append(c.pending, removed[K, V]{key, item.value, cache.REASON_FLUSHED})
+		}
+	}
+
+	c.items = make(map[K]*typedItem[V])
+	c.failures = make(map[K]failure)
+	c.cost = 0
+	c.policy.Reset()
+
+	c.unlock()
+
+	return true
+}
+
+// OnEvicted sets handler which is called for every item removed from cache
+// (expired, deleted, flushed or evicted due to size limits). Handler is called
+// synchronously after cache lock is released.
+func (c *TypedCache[K, V]) OnEvicted(handler func(key K, value V, reason cache.Reason)) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.onEvicted = handler
+	c.mu.Unlock()
+}
+
+// OnExpired sets handler which is called for every expired item removed from
+// cache
+func (c *TypedCache[K, V]) OnExpired(handler func(key K, value V)) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.onExpired = handler
+	c.mu.Unlock()
+}
+
+// Stop stops janitor goroutine
+func (c *TypedCache[K, V]) Stop() {
+	if c == nil || !c.isJanitorWorks || c.doneChan == nil {
+		return
+	}
+
+	c.stopOnce.Do(func() {
+		close(c.doneChan)
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Has returns true if cache contains data for given key
+func (a *cacheAdapter[V]) Has(key string) bool {
+	return a.c.Has(key)
+}
+
+// Size returns number of items in cache
+func (a *cacheAdapter[V]) Size() int {
+	return a.c.Size()
+}
+
+// Expired returns number of expired items in cache
+func (a *cacheAdapter[V]) Expired() int {
+	return a.c.Expired()
+}
+
+// Set adds or updates item in cache
+func (a *cacheAdapter[V]) Set(key string, data any, expiration ...time.Duration) bool {
+	value, ok := data.(V)
+
+	if !ok {
+		return false
+	}
+
+	return a.c.Set(key, value, expiration...)
+}
+
+// Get returns item from cache
+func (a *cacheAdapter[V]) Get(key string) any {
+	value, ok := a.c.Get(key)
+
+	if !ok {
+		return nil
+	}
+
+	return value
+}
+
+// GetExpiration returns item expiration date
+func (a *cacheAdapter[V]) GetExpiration(key string) time.Time {
+	return a.c.GetExpiration(key)
+}
+
+// GetWithExpiration returns item from cache and expiration date or nil
+func (a *cacheAdapter[V]) GetWithExpiration(key string) (any, time.Time) {
+	value, exp := a.c.GetWithExpiration(key)
+
+	if exp.IsZero() {
+		return nil, exp
+	}
+
+	return value, exp
+}
+
+// Keys is an iterator over cache keys
+func (a *cacheAdapter[V]) Keys(yield func(k string) bool) {
+	a.c.Keys(yield)
+}
+
+// All is an iterator over cache items
+func (a *cacheAdapter[V]) All(yield func(k string, v any) bool) {
+	for k, v := range a.c.All {
+		if !yield(k, v) {
+			return
+		}
+	}
+}
+
+// Delete removes item from cache
+func (a *cacheAdapter[V]) Delete(key string) bool {
+	return a.c.Delete(key)
+}
+
+// Invalidate deletes all expired records
+func (a *cacheAdapter[V]) Invalidate() bool {
+	return a.c.Invalidate()
+}
+
+// Flush removes all data from cache
+func (a *cacheAdapter[V]) Flush() bool {
+	return a.c.Flush()
+}
+
+// Stop stops janitor goroutine
+func (a *cacheAdapter[V]) Stop() {
+	a.c.Stop()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates cache configuration
+func (c TypedConfig[K]) Validate() error {
+	err := Config{
+		DefaultExpiration: c.DefaultExpiration,
+		CleanupInterval:   c.CleanupInterval,
+		ErrorExpiration:   c.ErrorExpiration,
+		RefreshAhead:      c.RefreshAhead,
+	}.Validate()
+
+	switch {
+	case err != nil:
+		return err
+	case c.MaxEntries < 0:
+		return errors.New("max entries can't be less than 0")
+	case c.MaxCost < 0:
+		return errors.New("max cost can't be less than 0")
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getItem returns non-expired item and updates statistics if required. Expired
+// items are removed if janitor doesn't work.
+func (c *TypedCache[K, V]) getItem(key K, now int64, track bool) *typedItem[V] {
+	item := c.items[key]
+
+	if item != nil && now > item.expiry {
+		if !c.isJanitorWorks {
+			c.remove(key, cache.REASON_EXPIRED)
+		}
+
+		item = nil
+	}
+
+	if !track {
+		return item
+	}
+
+	if item == nil {
+		c.stats.Misses++
+		return nil
+	}
+
+	c.stats.Hits++
+	c.policy.Access(key)
+
+	return item
+}
+
+// evict evicts items until cache size fits limits. It returns false if item
+// with given key was evicted.
+func (c *TypedCache[K, V]) evict(key K) bool {
+	result := true
+
+	for c.isOverLimit() {
+		victim, ok := c.policy.Victim(key)
+
+		if !ok {
+			break
+		}
+
+		item := c.items[victim]
+
+		if item != nil {
+			c.cost -= item.cost
+			delete(c.items, victim)
+			c.stats.Evictions++
+
+			if c.hasHandlers() {
+				c.pending = append(c.pending, removed[K, V]{victim, item.value, cache.REASON_CAPACITY})
+			}
+		}
+
+		if victim == key {
+			result = false
+		}
+	}
+
+	return result
+}
+
+// isOverLimit returns true if cache size exceeds limits
+func (c *TypedCache[K, V]) isOverLimit() bool {
+	return (c.maxEntries > 0 && len(c.items) > c.maxEntries) ||
+		(c.maxCost > 0 && c.cost > c.maxCost)
+}
+
+// remove removes item from cache and adds it to the queue for eviction handlers
+func (c *TypedCache[K, V]) remove(key K, reason cache.Reason) bool {
+	item := c.items[key]
+
+	if item == nil {
+		return false
+	}
+
+	c.cost -= item.cost
+	delete(c.items, key)
+	c.policy.Remove(key)
+
+	if c.hasHandlers() {
+		c.pending = append(c.pending, removed[K, V]{key, item.value, reason})
+	}
+
+	return true
+}
+
+// hasHandlers returns true if eviction handlers are set
+func (c *TypedCache[K, V]) hasHandlers() bool {
+	return c.onEvicted != nil || c.onExpired != nil
+}
+
+// unlock releases lock and passes all removed items to eviction handlers
+func (c *TypedCache[K, V]) unlock() {
+	pending, onEvicted, onExpired := c.pending, c.onEvicted, c.onExpired
+	c.pending = nil
+
+	c.mu.Unlock()
+
+	for _, item := range pending {
+		if onExpired != nil && item.reason == cache.REASON_EXPIRED {
+			onExpired(item.key, item.value)
+		}
+
+		if onEvicted != nil {
+			onEvicted(item.key, item.value, item.reason)
+		}
+	}
+}
+
+// load loads item using given loader function and adds it to cache
+func (c *TypedCache[K, V]) load(key K, loader func() (V, error)) (V, error) {
+	value, err := loader()
+
+	if err != nil {
+		if c.errorExpiration > 0 {
+			c.mu.Lock()
+			c.failures[key] = failure{err, time.Now().Add(c.errorExpiration).UnixNano()}
+			c.mu.Unlock()
+		}
+
+		var zero V
+		return zero, err
+	}
+
+	c.Set(key, value)
+
+	return value, nil
+}
+
+// janitor is cache cleanup job
+func (c *TypedCache[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+MAIN:
+	for {
+		select {
+		case <-ticker.C:
+			c.Invalidate()
+		case <-c.doneChan:
+			break MAIN
+		}
+	}
+
+	ticker.Stop()
+}
@@ -39,6 +39,10 @@ const (
 	_SYMBOL_INTERVAL = '/'
 	_SYMBOL_ENUM     = ','
 	_SYMBOL_ANY      = '*'
+	_SYMBOL_NO_VALUE = '?'
+	_SYMBOL_LAST     = 'L'
+	_SYMBOL_WEEKDAY  = 'W'
+	_SYMBOL_NTH      = '#'
 )
 
 const (
@@ -51,14 +55,22 @@ const (
 
 // Expr cron expression struct
 type Expr struct {
-	expression  string
-	minutes     []uint8
-	hours       []uint8
-	doms        []uint8
-	months      []uint8
-	dows        []uint8
-	domExplicit bool
-	dowExplicit bool
+	expression     string
+	location       *time.Location
+	seconds        []uint8
+	minutes        []uint8
+	hours          []uint8
+	doms           []uint8
+	months         []uint8
+	dows           []uint8
+	domWeekdays    []uint8  // Nearest weekdays (nW)
+	dowNths        []dowNth // Nth weekdays of month (x#n)
+	dowLasts       []uint8  // Last weekdays of month (xL)
+	domLast        bool     // Last day of month (L)
+	domLastWeekday bool     // Last weekday of month (LW)
+	domExplicit    bool
+	dowExplicit    bool
+	withSeconds    bool
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -69,20 +81,33 @@ type exprInfo struct {
 	nt  uint8 // Naming type
 }
 
+// dowNth contains info about nth weekday of month
+type dowNth struct {
+	dow uint8
+	n   uint8
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var (
 	// ErrMalformedExpression is returned by the Parse method if given cron expression has
 	// wrong or unsupported format
-	ErrMalformedExpression = errors.New("expression must have 5 tokens")
+	ErrMalformedExpression = errors.New("expression must have 5 or 6 tokens")
 
 	// ErrZeroInterval is returned if interval part of expression is empty
 	ErrZeroInterval = errors.New("interval can't be less or equals 0")
+
+	// ErrInvalidModifier is returned if L, W or # modifier has invalid value
+	ErrInvalidModifier = errors.New("invalid modifier value")
+
+	// ErrOutOfRange is returned if value is out of range allowed for the field
+	ErrOutOfRange = errors.New("value is out of range")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var info = []exprInfo{
+	{0, 59, _NAMES_NONE},
 	{0, 59, _NAMES_NONE},
 	{0, 23, _NAMES_NONE},
 	{1, 31, _NAMES_NONE},
@@ -94,24 +119,67 @@ var info = []exprInfo{
 
 // Parse parse cron expression
 // https://en.wikipedia.org/wiki/Cron
+//
+// Besides classic 5-field syntax, parser supports optional seconds field (as the
+// first field), Quartz-style modifiers L, W and # for day of month and day of week
+// fields, "?" as an alias for "*" and CRON_TZ=/TZ= prefix with time zone name.
 func Parse(expr string) (*Expr, error) {
-	expr = strings.ReplaceAll(expr, "\t", " ")
-	expr = getAliasExpression(expr)
+	expr = strings.TrimSpace(strings.ReplaceAll(expr, "\t", " "))
+	result := &Expr{}
+
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		tz, rest, _ := strings.Cut(expr, " ")
+		_, zone, _ := strings.Cut(tz, "=")
 
-	if strings.Count(expr, " ") < 4 {
+		loc, err := time.LoadLocation(zone)
+
+		if err != nil {
+			return nil, fmt.Errorf("can't load time zone %q: %w", zone, err)
+		}
+
+		result.location = loc
+		expr = strings.TrimSpace(rest)
+	}
+
+	expr = getAliasExpression(expr)
+	tokens := strings.Fields(expr)
+
+	switch len(tokens) {
+	case 5:
+		tokens = append([]string{"0"}, tokens...)
+	case 6:
+		result.withSeconds = true
+	default:
 		return nil, ErrMalformedExpression
 	}
 
-	result := &Expr{expression: expr}
+	if result.location != nil {
+		result.expression = "CRON_TZ=" + result.location.String() + " " + expr
+	} else {
+		result.expression = expr
+	}
 
 	for tn, ei := range info {
 		var err error
 		var data []uint8
 		var isAny bool
 
-		token := strutil.ReadField(expr, tn, true, ' ')
+		token := tokens[tn]
+
+		switch tn {
+		case 3:
+			token, err = result.parseDOMModifiers(token)
+		case 5:
+			token, err = result.parseDOWModifiers(token)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("can't parse token %q: %w", tokens[tn], err)
+		}
 
 		switch {
+		case token == "":
+			// Token contains only modifiers
 		case isAnyToken(token):
 			data = fillUintSlice(ei.min, ei.max, 1)
 			isAny = true
@@ -126,22 +194,24 @@ func Parse(expr string) (*Expr, error) {
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf("can't parse token %q: %w", token, err)
+			return nil, fmt.Errorf("can't parse token %q: %w", tokens[tn], err)
 		}
 
 		switch tn {
 		case 0:
-			result.minutes = data
+			result.seconds = data
 		case 1:
-			result.hours = data
+			result.minutes = data
 		case 2:
+			result.hours = data
+		case 3:
 			result.domExplicit = !isAny
 			result.doms = data
-		case 3:
-			result.months = data
 		case 4:
+			result.months = data
+		case 5:
 			result.dowExplicit = !isAny
-			result.dows = data
+			result.dows = normalizeDOW(data)
 		}
 	}
 
@@ -164,40 +234,33 @@ func (e *Expr) IsDue(args ...time.Time) bool {
 		t = time.Now()
 	}
 
-	if !slices.Contains(e.minutes, uint8(t.Minute())) {
-		return false
+	if e.location != nil {
+		t = t.In(e.location)
 	}
 
-	if !slices.Contains(e.hours, uint8(t.Hour())) {
+	if e.withSeconds && !slices.Contains(e.seconds, uint8(t.Second())) {
 		return false
 	}
 
-	if !slices.Contains(e.doms, uint8(t.Day())) {
-		if e.domExplicit && e.dowExplicit {
-			if !slices.Contains(e.dows, uint8(t.Weekday())) {
-				return false
-			}
-		} else {
-			return false
-		}
+	if !slices.Contains(e.minutes, uint8(t.Minute())) {
+		return false
 	}
 
-	if !slices.Contains(e.months, uint8(t.Month())) {
+	if !slices.Contains(e.hours, uint8(t.Hour())) {
 		return false
 	}
 
-	if !slices.Contains(e.dows, uint8(t.Weekday())) {
-		if e.domExplicit && e.dowExplicit {
-			return slices.Contains(e.doms, uint8(t.Day()))
-		} else {
-			return false
-		}
+	if !slices.Contains(e.months, uint8(t.Month())) {
+		return false
 	}
 
-	return true
+	return e.isDayMatch(t)
 }
 
-// Next get time of next matched moment
+// Next get time of next matched moment.
+//
+// Moments skipped due to DST transition are ignored, moments repeated due to DST
+// transition are matched only once.
 func (e *Expr) Next(args ...time.Time) time.Time {
 	if e == nil {
 		return time.Time{}
@@ -211,53 +274,60 @@ func (e *Expr) Next(args ...time.Time) time.Time {
 		t = time.Now()
 	}
 
-	year := t.Year()
-
-	mStart := getNearPrevIndex(e.months, uint8(t.Month()))
-	dStart := getNearPrevIndex(e.doms, uint8(t.Day()))
-
-	for y := year; y < year+5; y++ {
-		for i := mStart; i < len(e.months); i++ {
-			for j := dStart; j < len(e.doms); j++ {
-				for k := 0; k < len(e.hours); k++ {
-					for l := 0; l < len(e.minutes); l++ {
-						d := time.Date(
-							y,
-							time.Month(e.months[i]),
-							int(e.doms[j]),
-							int(e.hours[k]),
-							int(e.minutes[l]),
-							0, 0, t.Location(),
-						)
-
-						if d.Unix() <= t.Unix() {
-							continue
-						}
-
-						switch {
-						case uint8(d.Month()) != e.months[i],
-							uint8(d.Day()) != e.doms[j],
-							uint8(d.Hour()) != e.hours[k],
-							uint8(d.Minute()) != e.minutes[l],
-							!slices.Contains(e.dows, uint8(d.Weekday())):
-							continue
-						}
-
-						return d
+	if e.location != nil {
+		t = t.In(e.location)
+	}
+
+	loc := t.Location()
+	end := time.Date(t.Year()+5, 1, 1, 0, 0, 0, 0, loc)
+
+	for i := 0; ; i++ {
+		// We use noon as an anchor because midnight may not exist due to DST transition
+		day := time.Date(t.Year(), t.Month(), t.Day()+i, 12, 0, 0, 0, loc)
+
+		if !day.Before(end) {
+			break
+		}
+
+		if !slices.Contains(e.months, uint8(day.Month())) || !e.isDayMatch(day) {
+			continue
+		}
+
+		isFirst := i == 0
+
+		for _, h := range e.hours {
+			if isFirst && int(h) < t.Hour() {
+				continue
+			}
+
+			for _, m := range e.minutes {
+				if isFirst && int(h) == t.Hour() && int(m) < t.Minute() {
+					continue
+				}
+
+				for _, s := range e.seconds {
+					d := time.Date(
+						day.Year(), day.Month(), day.Day(),
+						int(h), int(m), int(s), 0, loc,
+					)
+
+					if d.Unix() <= t.Unix() || !isSameMoment(d, day, h, m, s) {
+						continue
 					}
+
+					return d
 				}
 			}
-
-			dStart = 0
 		}
-
-		mStart = 0
 	}
 
 	return time.Time{}
 }
 
 // Prev get time of prev matched moment
+//
+// Moments skipped due to DST transition are ignored, moments repeated due to DST
+// transition are matched only once.
 func (e *Expr) Prev(args ...time.Time) time.Time {
 	if e == nil {
 		return time.Time{}
@@ -271,52 +341,70 @@ func (e *Expr) Prev(args ...time.Time) time.Time {
 		t = time.Now()
 	}
 
-	year := t.Year()
-
-	mStart := getNearNextIndex(e.months, uint8(t.Month()))
-	dStart := getNearNextIndex(e.doms, uint8(t.Day()))
-
-	for y := year; y >= year-5; y-- {
-		for i := mStart; i >= 0; i-- {
-			for j := dStart; j >= 0; j-- {
-				for k := len(e.hours) - 1; k >= 0; k-- {
-					for l := len(e.minutes) - 1; l >= 0; l-- {
-						d := time.Date(
-							y,
-							time.Month(e.months[i]),
-							int(e.doms[j]),
-							int(e.hours[k]),
-							int(e.minutes[l]),
-							0, 0, t.Location(),
-						)
-
-						if d.Unix() >= t.Unix() {
-							continue
-						}
-
-						switch {
-						case uint8(d.Month()) != e.months[i],
-							uint8(d.Day()) != e.doms[j],
-							uint8(d.Hour()) != e.hours[k],
-							uint8(d.Minute()) != e.minutes[l],
-							!slices.Contains(e.dows, uint8(d.Weekday())):
-							continue
-						}
-
-						return d
+	if e.location != nil {
+		t = t.In(e.location)
+	}
+
+	loc := t.Location()
+	end := time.Date(t.Year()-5, 1, 1, 0, 0, 0, 0, loc)
+
+	for i := 0; ; i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()-i, 12, 0, 0, 0, loc)
+
+		if day.Before(end) {
+			break
+		}
+
+		if !slices.Contains(e.months, uint8(day.Month())) || !e.isDayMatch(day) {
+			continue
+		}
+
+		isFirst := i == 0
+
+		for k := len(e.hours) - 1; k >= 0; k-- {
+			h := e.hours[k]
+
+			if isFirst && int(h) > t.Hour() {
+				continue
+			}
+
+			for l := len(e.minutes) - 1; l >= 0; l-- {
+				m := e.minutes[l]
+
+				if isFirst && int(h) == t.Hour() && int(m) > t.Minute() {
+					continue
+				}
+
+				for n := len(e.seconds) - 1; n >= 0; n-- {
+					s := e.seconds[n]
+					d := time.Date(
+						day.Year(), day.Month(), day.Day(),
+						int(h), int(m), int(s), 0, loc,
+					)
+
+					if d.Unix() >= t.Unix() || !isSameMoment(d, day, h, m, s) {
+						continue
 					}
+
+					return d
 				}
 			}
-
-			dStart = len(e.doms) - 1
 		}
-
-		mStart = len(e.months) - 1
 	}
 
 	return time.Time{}
 }
 
+// Location returns time zone defined in expression using CRON_TZ/TZ prefix or
+// nil if time zone is not set
+func (e *Expr) Location() *time.Location {
+	if e == nil {
+		return nil
+	}
+
+	return e.location
+}
+
 // String return raw expression
 func (e *Expr) String() string {
 	if e == nil {
@@ -328,6 +416,165 @@ func (e *Expr) String() string {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// isDayMatch checks if given date matches day of month and day of week parts
+// of expression
+func (e *Expr) isDayMatch(t time.Time) bool {
+	domMatch := e.isDOMMatch(t)
+	dowMatch := e.isDOWMatch(t)
+
+	if e.domExplicit && e.dowExplicit {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+// isDOMMatch checks if given date matches day of month part of expression
+func (e *Expr) isDOMMatch(t time.Time) bool {
+	day := t.Day()
+
+	if slices.Contains(e.doms, uint8(day)) {
+		return true
+	}
+
+	switch {
+	case e.domLast && day == getDaysInMonth(t),
+		e.domLastWeekday && day == getLastWeekday(t):
+		return true
+	}
+
+	for _, d := range e.domWeekdays {
+		if day == getNearestWeekday(t, int(d)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDOWMatch checks if given date matches day of week part of expression
+func (e *Expr) isDOWMatch(t time.Time) bool {
+	dow := uint8(t.Weekday())
+
+	if slices.Contains(e.dows, dow) {
+		return true
+	}
+
+	for _, d := range e.dowNths {
+		if d.dow == dow && uint8((t.Day()-1)/7+1) == d.n {
+			return true
+		}
+	}
+
+	if slices.Contains(e.dowLasts, dow) && t.Day()+7 > getDaysInMonth(t) {
+		return true
+	}
+
+	return false
+}
+
+// parseDOMModifiers extracts L, LW and nW modifiers from day of month token and
+// returns token without them
+func (e *Expr) parseDOMModifiers(t string) (string, error) {
+	if t == string(_SYMBOL_NO_VALUE) {
+		return string(_SYMBOL_ANY), nil
+	}
+
+	if !strings.ContainsAny(t, "LlWw") {
+		return t, nil
+	}
+
+	var rest []string
+
+	for item := range strings.SplitSeq(t, string(_SYMBOL_ENUM)) {
+		switch strings.ToUpper(item) {
+		case "L":
+			e.domLast = true
+			continue
+		case "LW":
+			e.domLastWeekday = true
+			continue
+		}
+
+		if strings.ToUpper(strutil.Tail(item, 1)) != string(_SYMBOL_WEEKDAY) {
+			rest = append(rest, item)
+			continue
+		}
+
+		d, err := str2uint(strutil.Substr(item, 0, len(item)-1))
+
+		if err != nil {
+			return "", err
+		}
+
+		if d < 1 || d > 31 {
+			return "", ErrInvalidModifier
+		}
+
+		e.domWeekdays = append(e.domWeekdays, d)
+	}
+
+	return strings.Join(rest, string(_SYMBOL_ENUM)), nil
+}
+
+// parseDOWModifiers extracts x#n and xL modifiers from day of week token and
+// returns token without them
+func (e *Expr) parseDOWModifiers(t string) (string, error) {
+	if t == string(_SYMBOL_NO_VALUE) {
+		return string(_SYMBOL_ANY), nil
+	}
+
+	if !strings.ContainsAny(t, "Ll#") {
+		return t, nil
+	}
+
+	var rest []string
+
+	for item := range strings.SplitSeq(t, string(_SYMBOL_ENUM)) {
+		switch {
+		case strings.ContainsRune(item, _SYMBOL_NTH):
+			dt, nt, _ := strings.Cut(item, string(_SYMBOL_NTH))
+			dow, err := parseToken(dt, _NAMES_DAYS)
+
+			if err != nil {
+				return "", err
+			}
+
+			n, err := str2uint(nt)
+
+			if err != nil {
+				return "", err
+			}
+
+			if dow > 7 || n < 1 || n > 5 {
+				return "", ErrInvalidModifier
+			}
+
+			e.dowNths = append(e.dowNths, dowNth{dow % 7, n})
+
+		case len(item) > 1 && strings.ToUpper(strutil.Tail(item, 1)) == string(_SYMBOL_LAST):
+			dow, err := parseToken(strutil.Substr(item, 0, len(item)-1), _NAMES_DAYS)
+
+			if err != nil {
+				return "", err
+			}
+
+			if dow > 7 {
+				return "", ErrInvalidModifier
+			}
+
+			e.dowLasts = append(e.dowLasts, dow%7)
+
+		default:
+			rest = append(rest, item)
+		}
+	}
+
+	return strings.Join(rest, string(_SYMBOL_ENUM)), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // isAnyToken checks if the token is a wildcard token (*)
 func isAnyToken(t string) bool {
 	return t == string(_SYMBOL_ANY)
@@ -367,7 +614,7 @@ func parseEnumToken(t string, ei exprInfo) ([]uint8, error) {
 			result = append(result, d...)
 
 		default:
-			v, err := parseToken(tt, ei.nt)
+			v, err := parseValueToken(tt, ei)
 
 			if err != nil {
 				return nil, err
@@ -395,8 +642,8 @@ func parsePeriodToken(t string, ei exprInfo) ([]uint8, error) {
 	}
 
 	return fillUintSlice(
-		mathutil.Between(t1, ei.min, ei.max),
-		mathutil.Between(t2, ei.min, ei.max),
+		mathutil.Between(t1, ei.min, getMaxValue(ei)),
+		mathutil.Between(t2, ei.min, getMaxValue(ei)),
 		1,
 	), nil
 }
@@ -418,7 +665,7 @@ func parseIntervalToken(t string, ei exprInfo) ([]uint8, error) {
 
 // parseSimpleToken parses a simple token without any special characters
 func parseSimpleToken(t string, ei exprInfo) ([]uint8, error) {
-	v, err := parseToken(t, ei.nt)
+	v, err := parseValueToken(t, ei)
 
 	if err != nil {
 		return nil, err
@@ -466,6 +713,49 @@ func parseToken(t string, nt uint8) (uint8, error) {
 	return str2uint(t)
 }
 
+// parseValueToken parses a token with single value and checks that value is in
+// the allowed range
+func parseValueToken(t string, ei exprInfo) (uint8, error) {
+	v, err := parseToken(t, ei.nt)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if v < ei.min || v > getMaxValue(ei) {
+		return 0, ErrOutOfRange
+	}
+
+	return v, nil
+}
+
+// getMaxValue returns maximum value allowed for the field. For day of week field
+// 7 is allowed as an alias for Sunday.
+func getMaxValue(ei exprInfo) uint8 {
+	if ei.nt == _NAMES_DAYS {
+		return 7
+	}
+
+	return ei.max
+}
+
+// normalizeDOW replaces 7 (alias for Sunday) with 0 in day of week values
+func normalizeDOW(dows []uint8) []uint8 {
+	if !slices.Contains(dows, 7) {
+		return dows
+	}
+
+	for i, d := range dows {
+		if d == 7 {
+			dows[i] = 0
+		}
+	}
+
+	slices.Sort(dows)
+
+	return slices.Compact(dows)
+}
+
 // getDayNumByName returns the numeric representation of a day name
 func getDayNumByName(token string) (uint8, bool) {
 	switch strings.ToLower(token) {
@@ -520,6 +810,56 @@ func getMonthNumByName(token string) (uint8, bool) {
 	return 0, false
 }
 
+// getDaysInMonth returns number of days in month of given date
+func getDaysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 12, 0, 0, 0, t.Location()).Day()
+}
+
+// getLastWeekday returns last weekday (Monday-Friday) of month of given date
+func getLastWeekday(t time.Time) int {
+	last := time.Date(t.Year(), t.Month()+1, 0, 12, 0, 0, 0, t.Location())
+
+	switch last.Weekday() {
+	case time.Saturday:
+		return last.Day() - 1
+	case time.Sunday:
+		return last.Day() - 2
+	}
+
+	return last.Day()
+}
+
+// getNearestWeekday returns weekday (Monday-Friday) nearest to the given day of
+// month. Result is always within the same month.
+func getNearestWeekday(t time.Time, day int) int {
+	days := getDaysInMonth(t)
+	day = min(day, days)
+
+	switch time.Date(t.Year(), t.Month(), day, 12, 0, 0, 0, t.Location()).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+
+		return day - 1
+
+	case time.Sunday:
+		if day == days {
+			return day - 2
+		}
+
+		return day + 1
+	}
+
+	return day
+}
+
+// isSameMoment checks if date was not normalized due to DST transition
+func isSameMoment(d, day time.Time, h, m, s uint8) bool {
+	return d.Day() == day.Day() && d.Hour() == int(h) &&
+		d.Minute() == int(m) && d.Second() == int(s)
+}
+
 // fillUintSlice fills a slice with uint8 values from start to end with a given interval
 func fillUintSlice(start, end, interval uint8) []uint8 {
 	var result []uint8
@@ -541,27 +881,3 @@ func str2uint(t string) (uint8, error) {
 
 	return uint8(u), nil
 }
-
-// getNearNextIndex finds the index of the nearest item in the slice that is greater than
-// or equal to the given item
-func getNearNextIndex(items []uint8, item uint8) int {
-	for i := range len(items) {
-		if items[i] >= item {
-			return i
-		}
-	}
-
-	return 0
-}
-
-// getNearPrevIndex finds the index of the nearest item in the slice that is less than
-// or equal to the given item
-func getNearPrevIndex(items []uint8, item uint8) int {
-	for i := len(items) - 1; i >= 0; i-- {
-		if items[i] <= item {
-			return i
-		}
-	}
-
-	return len(items) - 1
-}
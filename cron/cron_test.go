@@ -8,10 +8,15 @@ package cron
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/essentialkaos/check"
+
+	"github.com/essentialkaos/ek/v14/events"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -111,7 +116,7 @@ func (s *CronSuite) TestParsing(c *C) {
 
 	c.Assert(
 		e9.Prev(time.Date(2015, 1, 1, 0, 0, 0, 0, time.Local)),
-		DeepEquals, time.Time{},
+		DeepEquals, time.Date(2014, 1, 27, 12, 0, 0, 0, time.Local),
 	)
 
 	e10, err := Parse("0 12 1 1 Wed")
@@ -121,7 +126,7 @@ func (s *CronSuite) TestParsing(c *C) {
 
 	c.Assert(
 		e10.Next(time.Date(2015, 6, 1, 0, 0, 0, 0, time.Local)),
-		DeepEquals, time.Time{},
+		DeepEquals, time.Date(2016, 1, 1, 12, 0, 0, 0, time.Local),
 	)
 
 	e11, err := Parse("45 17 7 0-5 1")
@@ -157,6 +162,233 @@ func (s *CronSuite) TestParsing(c *C) {
 	)
 }
 
+func (s *CronSuite) TestSeconds(c *C) {
+	e, err := Parse("*/10 30 12 * * ?")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.String(), Equals, "*/10 30 12 * * ?")
+	c.Assert(e.IsDue(time.Date(2026, 3, 1, 12, 30, 20, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 3, 1, 12, 30, 21, 0, time.UTC)), Equals, false)
+
+	c.Assert(
+		e.Next(time.Date(2026, 3, 1, 12, 30, 20, 0, time.UTC)),
+		Equals, time.Date(2026, 3, 1, 12, 30, 30, 0, time.UTC),
+	)
+
+	c.Assert(
+		e.Next(time.Date(2026, 3, 1, 12, 30, 55, 0, time.UTC)),
+		Equals, time.Date(2026, 3, 2, 12, 30, 0, 0, time.UTC),
+	)
+
+	c.Assert(
+		e.Prev(time.Date(2026, 3, 1, 12, 30, 20, 0, time.UTC)),
+		Equals, time.Date(2026, 3, 1, 12, 30, 10, 0, time.UTC),
+	)
+
+	c.Assert(
+		e.Prev(time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)),
+		Equals, time.Date(2026, 2, 28, 12, 30, 50, 0, time.UTC),
+	)
+
+	_, err = Parse("* * * * * * *")
+	c.Assert(err, Equals, ErrMalformedExpression)
+}
+
+func (s *CronSuite) TestModifiers(c *C) {
+	// Last day of month
+	e, err := Parse("0 0 L * *")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.IsDue(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)), Equals, false)
+
+	c.Assert(
+		e.Next(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+		Equals, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+	)
+
+	c.Assert(
+		e.Prev(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+		Equals, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+	)
+
+	// Last day of month and 15th day of month
+	e, err = Parse("0 0 15,L * *")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.IsDue(time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)), Equals, true)
+
+	// Last weekday of month (2026-05-31 is Sunday)
+	e, err = Parse("0 0 LW * *")
+
+	c.Assert(err, IsNil)
+
+	c.Assert(
+		e.Next(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)),
+		Equals, time.Date(2026, 5, 29, 0, 0, 0, 0, time.UTC),
+	)
+
+	// Nearest weekday (2026-02-15 is Sunday, 2026-08-01 is Saturday,
+	// 2026-05-31 is Sunday)
+	e, err = Parse("0 0 15W,1w,31W * *")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.IsDue(time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)), Equals, false)
+	c.Assert(e.IsDue(time.Date(2026, 8, 3, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 5, 29, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)), Equals, true)
+
+	// Second Friday of month
+	e, err = Parse("0 9 ? * Fri#2")
+
+	c.Assert(err, IsNil)
+
+	c.Assert(
+		e.Next(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)),
+		Equals, time.Date(2026, 10, 9, 9, 0, 0, 0, time.UTC),
+	)
+
+	c.Assert(
+		e.Prev(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)),
+		Equals, time.Date(2026, 9, 11, 9, 0, 0, 0, time.UTC),
+	)
+
+	// Last Monday of month and every Sunday
+	e, err = Parse("0 9 * * 1L,0")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.IsDue(time.Date(2026, 8, 31, 9, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 8, 24, 9, 0, 0, 0, time.UTC)), Equals, false)
+	c.Assert(e.IsDue(time.Date(2026, 8, 30, 9, 0, 0, 0, time.UTC)), Equals, true)
+
+	// 7 is an alias for Sunday
+	e, err = Parse("0 9 * * 7L,7#1")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.IsDue(time.Date(2026, 8, 30, 9, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 8, 2, 9, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 8, 9, 9, 0, 0, 0, time.UTC)), Equals, false)
+
+	for _, expr := range []string{
+		"0 0 32W * *", "0 0 AW * *", "0 0 * * 8L", "0 0 * * AL",
+		"0 0 * * 1#6", "0 0 * * A#1", "0 0 * * 1#A", "0 0 * * 8#1",
+	} {
+		_, err = Parse(expr)
+		c.Assert(err, NotNil, Commentf("Expression %q", expr))
+	}
+
+	c.Assert(getNearestWeekday(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 31), Equals, 27)
+	c.Assert(getLastWeekday(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)), Equals, 30)
+	c.Assert(getLastWeekday(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)), Equals, 30)
+}
+
+func (s *CronSuite) TestDayMatching(c *C) {
+	// If both day of month and day of week are restricted, moment matches if
+	// either of them matches
+	e, err := Parse("0 0 1 * MON")
+
+	c.Assert(err, IsNil)
+
+	t := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+
+	c.Assert(e.Next(t), DeepEquals, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC))
+	c.Assert(e.Prev(t), DeepEquals, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	c.Assert(
+		e.Next(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)),
+		DeepEquals, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	)
+	c.Assert(
+		e.Prev(time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC)),
+		DeepEquals, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	for d := range e.Upcoming(20, t) {
+		c.Assert(e.IsDue(d), Equals, true, Commentf("Date: %v", d))
+	}
+
+	// If only one of day parts is restricted, it must match
+	e, err = Parse("0 0 * * MON")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.Next(t), DeepEquals, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC))
+
+	e, err = Parse("0 0 15 * ?")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.Next(t), DeepEquals, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+	c.Assert(e.Prev(t), DeepEquals, time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *CronSuite) TestTimeZone(c *C) {
+	e, err := Parse("CRON_TZ=America/New_York 0 9 * * *")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.String(), Equals, "CRON_TZ=America/New_York 0 9 * * *")
+	c.Assert(e.Location().String(), Equals, "America/New_York")
+	c.Assert(e.IsDue(time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(e.IsDue(time.Date(2026, 7, 6, 13, 0, 0, 0, time.UTC)), Equals, true)
+
+	c.Assert(
+		e.Next(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)).UTC(),
+		Equals, time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC),
+	)
+
+	e, err = Parse("TZ=UTC @daily")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.String(), Equals, "CRON_TZ=UTC 0 0 * * *")
+
+	_, err = Parse("TZ=Unknown/Zone * * * * *")
+	c.Assert(err, NotNil)
+
+	var ne *Expr
+	c.Assert(ne.Location(), IsNil)
+}
+
+func (s *CronSuite) TestDST(c *C) {
+	// 2026-03-08 02:00 → 03:00 in America/New_York
+	e, err := Parse("CRON_TZ=America/New_York 30 2 * * *")
+
+	c.Assert(err, IsNil)
+
+	loc := e.Location()
+
+	c.Assert(
+		e.Next(time.Date(2026, 3, 7, 12, 0, 0, 0, loc)),
+		Equals, time.Date(2026, 3, 9, 2, 30, 0, 0, loc),
+	)
+
+	c.Assert(
+		e.Prev(time.Date(2026, 3, 9, 0, 0, 0, 0, loc)),
+		Equals, time.Date(2026, 3, 7, 2, 30, 0, 0, loc),
+	)
+
+	// 2026-11-01 02:00 → 01:00 in America/New_York
+	e, err = Parse("CRON_TZ=America/New_York 30 1 * * *")
+
+	c.Assert(err, IsNil)
+
+	d1 := e.Next(time.Date(2026, 11, 1, 0, 0, 0, 0, loc))
+	d2 := e.Next(d1)
+
+	c.Assert(d1.Day(), Equals, 1)
+	c.Assert(d1.Hour(), Equals, 1)
+	c.Assert(d2.Day(), Equals, 2)
+	c.Assert(d2.Hour(), Equals, 1)
+
+	// Every hour
+	e, err = Parse("CRON_TZ=America/New_York 0 * * * *")
+
+	c.Assert(err, IsNil)
+
+	c.Assert(
+		e.Next(time.Date(2026, 3, 8, 1, 30, 0, 0, loc)).Unix(),
+		Equals, time.Date(2026, 3, 8, 3, 0, 0, 0, loc).Unix(),
+	)
+}
+
 func (s *CronSuite) TestAliases(c *C) {
 	c.Assert(getAliasExpression("@yearly"), Equals, YEARLY)
 	c.Assert(getAliasExpression("@annually"), Equals, ANNUALLY)
@@ -233,15 +465,317 @@ func (s *CronSuite) TestAliases(c *C) {
 	c.Assert(mn13Ok, Equals, false)
 }
 
-func (s *CronSuite) TestNearIndex(c *C) {
-	items := []uint8{1, 2, 3, 4, 6, 7, 8, 9}
+func (s *CronSuite) TestDescribe(c *C) {
+	cases := map[string]string{
+		"*/15 2-5 * * 1-5":             "every 15 minutes, between 02:00 and 05:59, Monday through Friday",
+		"* * * * *":                    "every minute",
+		"0 9 * * Mon-Fri":              "at 09:00, Monday through Friday",
+		"30 0,6,12,18 * * *":           "at 00:30, 06:30, 12:30 and 18:30",
+		"0 */2 * * *":                  "at 0 minutes past the hour, every 2 hours",
+		"0 * 2,5,8,11,14,17,20 * *":    "at 0 minutes past the hour, on days 2, 5, 8, 11, 14, 17 and 20 of the month",
+		"0 1,3,5,7,9,11,13 * * *":      "at 0 minutes past the hour, during hours 01, 03, 05, 07, 09, 11 and 13",
+		"5,10 * 1,15 * *":              "at 5 and 10 minutes past the hour, on days 1 and 15 of the month",
+		"0 0 1-10 * *":                 "at 00:00, on days 1 through 10 of the month",
+		"0 0 */2 * *":                  "at 00:00, every 2 days",
+		"0 0 L * *":                    "at 00:00, on the last day of the month",
+		"0 0 LW * *":                   "at 00:00, on the last weekday of the month",
+		"0 0 15W * *":                  "at 00:00, on the weekday nearest day 15 of the month",
+		"0 0 * * Fri#2":                "at 00:00, on the second Friday of the month",
+		"0 0 * * 5L":                   "at 00:00, on the last Friday of the month",
+		"0 0 * * 1,3,5":                "at 00:00, on Monday, Wednesday and Friday",
+		"0 0 1 * 1":                    "at 00:00, on day 1 of the month or on Monday",
+		"*/10 * * * * *":               "every 10 seconds",
+		"* * * * * *":                  "every second",
+		"30 * * * * *":                 "at 30 seconds past the minute",
+		"10-20 */5 * * * *":            "seconds 10 through 20 past the minute, every 5 minutes",
+		"15 30 4 * * *":                "at 04:30:15",
+		"0 0 1 1 *":                    "at 00:00, on day 1 of the month, in January",
+		"0 0 1 */3 *":                  "at 00:00, on day 1 of the month, every 3 months",
+		"10-20 * * Jan-Mar *":          "minutes 10 through 20 past the hour, January through March",
+		"0 0 1 1,6 *":                  "at 00:00, on day 1 of the month, in January and June",
+		"CRON_TZ=Asia/Tokyo 0 9 * * *": "at 09:00, in Asia/Tokyo time zone",
+		"0 0 * * 0,7":                  "at 00:00, on Sunday",
+		"0 0 * * 5-7":                  "at 00:00, on Sunday, Friday and Saturday",
+		"0 0 * * 7#1":                  "at 00:00, on the first Sunday of the month",
+	}
+
+	for expr, desc := range cases {
+		e, err := Parse(expr)
+
+		c.Assert(err, IsNil)
+		c.Assert(e.Describe(), Equals, desc, Commentf("Expression: %s", expr))
+	}
+
+	e, _ := Parse("0 9 * * *")
+	l := *LocaleEN
+	l.AtTime = "в %s"
+
+	c.Assert(e.Describe(&l), Equals, "в 09:00")
+	c.Assert(e.Describe(nil), Equals, "at 09:00")
+
+	c.Assert(getLocaleName(LocaleEN.Months[:], 12), Equals, "12")
+	c.Assert(getLocaleName(LocaleEN.Days[:], -1), Equals, "-1")
+	c.Assert(getLocaleName(LocaleEN.Days[:], 0), Equals, "Sunday")
+}
+
+func (s *CronSuite) TestUpcoming(c *C) {
+	e, _ := Parse("0 */6 * * *")
+	t := time.Date(2025, 1, 1, 10, 0, 0, 0, time.Local)
+
+	var result []time.Time
+
+	for d := range e.Upcoming(3, t) {
+		result = append(result, d)
+	}
+
+	c.Assert(result, HasLen, 3)
+	c.Assert(result[0].Unix(), Equals, time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local).Unix())
+	c.Assert(result[1].Unix(), Equals, time.Date(2025, 1, 1, 18, 0, 0, 0, time.Local).Unix())
+	c.Assert(result[2].Unix(), Equals, time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local).Unix())
+
+	result = nil
+
+	for d := range e.Upcoming(10, t) {
+		result = append(result, d)
+
+		if len(result) == 2 {
+			break
+		}
+	}
+
+	c.Assert(result, HasLen, 2)
+
+	e, _ = Parse("0 0 30 2 *")
+
+	for range e.Upcoming(3, t) {
+		c.Fatal("Expression without matches must not produce any dates")
+	}
+
+	count := 0
+
+	for range e.Upcoming(3) {
+		count++
+	}
+
+	c.Assert(count, Equals, 0)
+}
+
+func (s *CronSuite) TestScheduler(c *C) {
+	d := events.NewDispatcher()
+	evs := make(chan string, 100)
+
+	for _, ev := range []string{EV_JOB_START, EV_JOB_FINISH, EV_JOB_FAIL, EV_JOB_SKIP} {
+		d.AddHandler(ev, func(payload any) {
+			evs <- ev + ":" + payload.(*JobEvent).Name
+		})
+	}
+
+	sc := NewScheduler(d)
+
+	var okRuns, failRuns atomic.Int32
+
+	c.Assert(sc.AddFunc("ok", "* * * * * *", func(ctx context.Context) error {
+		okRuns.Add(1)
+		return nil
+	}), IsNil)
+
+	c.Assert(sc.AddFunc("fail", "* * * * * *", func(ctx context.Context) error {
+		failRuns.Add(1)
+		return errors.New("error")
+	}), IsNil)
+
+	c.Assert(sc.AddFunc("panic", "* * * * * *", func(ctx context.Context) error {
+		panic("test")
+	}), IsNil)
+
+	c.Assert(sc.AddFunc("yearly", YEARLY, func(ctx context.Context) error {
+		return nil
+	}), IsNil)
+
+	jobs := sc.Jobs()
+
+	c.Assert(jobs, HasLen, 4)
+	c.Assert(jobs[0].Name, Equals, "fail")
+	c.Assert(jobs[3].Name, Equals, "yearly")
+	c.Assert(jobs[3].Next.After(jobs[0].Next), Equals, true)
+
+	c.Assert(sc.IsRunning(), Equals, false)
+	c.Assert(sc.Start(), IsNil)
+	c.Assert(sc.Start(), Equals, ErrSchedulerStarted)
+	c.Assert(sc.IsRunning(), Equals, true)
+
+	time.Sleep(2200 * time.Millisecond)
+
+	c.Assert(sc.Stop(), IsNil)
+	c.Assert(sc.Stop(), Equals, ErrSchedulerStopped)
+	c.Assert(okRuns.Load() >= 2, Equals, true)
+	c.Assert(failRuns.Load() >= 2, Equals, true)
+
+	received := map[string]bool{}
+
+	for len(evs) > 0 {
+		received[<-evs] = true
+	}
+
+	c.Assert(received["job.start:ok"], Equals, true)
+	c.Assert(received["job.finish:ok"], Equals, true)
+	c.Assert(received["job.fail:fail"], Equals, true)
+	c.Assert(received["job.fail:panic"], Equals, true)
+	c.Assert(received["job.finish:yearly"], Equals, false)
 
-	c.Assert(getNearNextIndex(items, 5), Equals, 4)
-	c.Assert(getNearNextIndex(items, 6), Equals, 4)
-	c.Assert(getNearNextIndex(items, 10), Equals, 0)
-	c.Assert(getNearPrevIndex(items, 5), Equals, 3)
-	c.Assert(getNearPrevIndex(items, 6), Equals, 4)
-	c.Assert(getNearPrevIndex(items, 0), Equals, 7)
+	c.Assert(sc.Remove("ok"), Equals, true)
+	c.Assert(sc.Remove("ok"), Equals, false)
+	c.Assert(sc.Jobs(), HasLen, 3)
+}
+
+func (s *CronSuite) TestSchedulerOverlap(c *C) {
+	d := events.NewDispatcher()
+	var skipped atomic.Int32
+
+	d.AddHandler(EV_JOB_SKIP, func(payload any) {
+		skipped.Add(1)
+	})
+
+	sc := NewScheduler(d)
+	expr, _ := Parse("* * * * * *")
+
+	var skipRuns, queueRuns, allowRuns, maxAllow, allowActive atomic.Int32
+
+	slowFunc := func(counter *atomic.Int32) JobFunc {
+		return func(ctx context.Context) error {
+			counter.Add(1)
+			time.Sleep(1500 * time.Millisecond)
+			return nil
+		}
+	}
+
+	c.Assert(sc.Add(Job{Name: "skip", Expr: expr, Func: slowFunc(&skipRuns)}), IsNil)
+	c.Assert(sc.Add(Job{Name: "queue", Expr: expr, Func: slowFunc(&queueRuns), Overlap: OVERLAP_QUEUE}), IsNil)
+	c.Assert(sc.Add(Job{
+		Name: "allow", Expr: expr, Overlap: OVERLAP_ALLOW,
+		Func: func(ctx context.Context) error {
+			allowRuns.Add(1)
+			maxAllow.Store(max(maxAllow.Load(), allowActive.Add(1)))
+			time.Sleep(1500 * time.Millisecond)
+			allowActive.Add(-1)
+			return nil
+		},
+	}), IsNil)
+
+	c.Assert(sc.Start(), IsNil)
+
+	for range 60 {
+		if queueRuns.Load() >= 3 {
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	jobs := sc.Jobs()
+
+	c.Assert(jobs, HasLen, 3)
+	c.Assert(jobs[0].Last.IsZero(), Equals, false)
+
+	c.Assert(sc.Stop(), IsNil)
+
+	c.Assert(skipRuns.Load() < allowRuns.Load(), Equals, true)
+	c.Assert(queueRuns.Load() > skipRuns.Load(), Equals, true)
+	c.Assert(maxAllow.Load() > 1, Equals, true)
+	c.Assert(skipped.Load() > 0, Equals, true)
+}
+
+func (s *CronSuite) TestSchedulerSlowHandlers(c *C) {
+	d := events.NewDispatcher()
+
+	for _, ev := range []string{EV_JOB_START, EV_JOB_FINISH, EV_JOB_SKIP} {
+		d.AddHandler(ev, func(payload any) {
+			time.Sleep(3 * time.Second)
+		})
+	}
+
+	sc := NewScheduler(d)
+
+	var runs atomic.Int32
+
+	c.Assert(sc.AddFunc("slow", "* * * * * *", func(ctx context.Context) error {
+		time.Sleep(1500 * time.Millisecond)
+		return nil
+	}), IsNil)
+
+	c.Assert(sc.AddFunc("fast", "* * * * * *", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}), IsNil)
+
+	c.Assert(sc.Start(), IsNil)
+
+	time.Sleep(3200 * time.Millisecond)
+
+	c.Assert(sc.Stop(), IsNil)
+	c.Assert(runs.Load() >= 3, Equals, true)
+}
+
+func (s *CronSuite) TestSchedulerTimeout(c *C) {
+	sc := NewScheduler(nil)
+	expr, _ := Parse("* * * * * *")
+
+	var jitterDelay atomic.Int64
+	errChan := make(chan error, 10)
+
+	c.Assert(sc.Add(Job{
+		Name: "timeout", Expr: expr, Timeout: 50 * time.Millisecond,
+		Func: func(ctx context.Context) error {
+			<-ctx.Done()
+			errChan <- ctx.Err()
+			return ctx.Err()
+		},
+	}), IsNil)
+
+	c.Assert(sc.Add(Job{
+		Name: "jitter", Expr: expr, Jitter: 200 * time.Millisecond,
+		Func: func(ctx context.Context) error {
+			jitterDelay.Store(int64(time.Now().Nanosecond()))
+			return nil
+		},
+	}), IsNil)
+
+	c.Assert(sc.Start(), IsNil)
+
+	select {
+	case err := <-errChan:
+		c.Assert(err, Equals, context.DeadlineExceeded)
+	case <-time.After(3 * time.Second):
+		c.Fatal("Job wasn't executed")
+	}
+
+	c.Assert(sc.Stop(), IsNil)
+	c.Assert(time.Duration(jitterDelay.Load()) < 400*time.Millisecond, Equals, true)
+}
+
+func (s *CronSuite) TestSchedulerErrors(c *C) {
+	sc := NewScheduler(nil)
+	expr, _ := Parse("* * * * *")
+	fn := func(ctx context.Context) error { return nil }
+
+	c.Assert(sc.Add(Job{Expr: expr, Func: fn}), Equals, ErrEmptyJobName)
+	c.Assert(sc.Add(Job{Name: "test", Func: fn}), Equals, ErrNilJobExpr)
+	c.Assert(sc.Add(Job{Name: "test", Expr: expr}), Equals, ErrNilJobFunc)
+	c.Assert(sc.Add(Job{Name: "test", Expr: expr, Func: fn, Overlap: 10}), Equals, ErrUnknownOverlap)
+	c.Assert(sc.AddFunc("test", "* * *", fn), Equals, ErrMalformedExpression)
+	c.Assert(sc.AddFunc("test", "* * * * *", fn), IsNil)
+	c.Assert(sc.AddFunc("test", "* * * * *", fn), ErrorMatches, `job "test" already exists`)
+	c.Assert(sc.Stop(), Equals, ErrSchedulerStopped)
+
+	var nsc *Scheduler
+
+	c.Assert(nsc.Add(Job{}), Equals, ErrNilScheduler)
+	c.Assert(nsc.AddFunc("test", "* * * * *", fn), Equals, ErrNilScheduler)
+	c.Assert(nsc.Remove("test"), Equals, false)
+	c.Assert(nsc.Jobs(), IsNil)
+	c.Assert(nsc.Start(), Equals, ErrNilScheduler)
+	c.Assert(nsc.Stop(), Equals, ErrNilScheduler)
+	c.Assert(nsc.IsRunning(), Equals, false)
 }
 
 func (s *CronSuite) TestErrors(c *C) {
@@ -286,6 +820,16 @@ func (s *CronSuite) TestErrors(c *C) {
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, `can't parse token "0,1,2-A": strconv.ParseUint: parsing "A": invalid syntax`)
 	c.Assert(e, IsNil)
+
+	for _, expr := range []string{
+		"99 0 * * *", "0 24 * * *", "0 0 0 * *", "0 0 32 * *",
+		"0 0 * 13 *", "0 0 * 0 *", "0 0 * * 8", "0 0 * * 1,8", "60 * * * * *",
+	} {
+		e, err = Parse(expr)
+
+		c.Assert(err, ErrorMatches, `can't parse token ".*": value is out of range`, Commentf("Expression %q", expr))
+		c.Assert(e, IsNil)
+	}
 }
 
 func (s *CronSuite) TestNil(c *C) {
@@ -295,4 +839,9 @@ func (s *CronSuite) TestNil(c *C) {
 	c.Assert(e.Next(time.Time{}), DeepEquals, time.Time{})
 	c.Assert(e.Prev(time.Time{}), DeepEquals, time.Time{})
 	c.Assert(e.String(), Equals, "Expr{nil}")
+	c.Assert(e.Describe(), Equals, "")
+
+	for range e.Upcoming(10) {
+		c.Fatal("Nil expression must not produce any dates")
+	}
 }
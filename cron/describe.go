@@ -0,0 +1,456 @@
+package cron
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Locale contains text pieces used for building human-readable description of
+// expression. All strings with verbs are formatted using fmt.Sprintf.
+type Locale struct {
+	EverySecond    string // every second
+	EveryMinute    string // every minute
+	EveryNSeconds  string // every %d seconds
+	EveryNMinutes  string // every %d minutes
+	EveryNHours    string // every %d hours
+	EveryNDays     string // every %d days
+	EveryNMonths   string // every %d months
+	AtTime         string // at %s
+	AtSeconds      string // at %s seconds past the minute
+	AtMinutes      string // at %s minutes past the hour
+	SecondsRange   string // seconds %s through %s past the minute
+	MinutesRange   string // minutes %s through %s past the hour
+	HoursRange     string // between %s and %s
+	DuringHours    string // during hours %s
+	OnDay          string // on day %s of the month
+	OnDays         string // on days %s of the month
+	DaysRange      string // on days %s through %s of the month
+	LastDay        string // on the last day of the month
+	LastWeekday    string // on the last weekday of the month
+	NearestWeekday string // on the weekday nearest day %s of the month
+	OnWeekdays     string // on %s
+	WeekdaysRange  string // %s through %s
+	NthWeekday     string // on the %s %s of the month
+	LastWeekdayOf  string // on the last %s of the month
+	InMonths       string // in %s
+	MonthsRange    string // %s through %s
+	TimeZone       string // in %s time zone
+	Separator      string // ", "
+	And            string // " and "
+	Or             string // " or "
+
+	Ordinals [5]string  // first, second, third, fourth, fifth
+	Days     [7]string  // Sunday, Monday, …
+	Months   [12]string // January, February, …
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LocaleEN is English locale used by default
+var LocaleEN = &Locale{
+	EverySecond:    "every second",
+	EveryMinute:    "every minute",
+	EveryNSeconds:  "every %d seconds",
+	EveryNMinutes:  "every %d minutes",
+	EveryNHours:    "every %d hours",
+	EveryNDays:     "every %d days",
+	EveryNMonths:   "every %d months",
+	AtTime:         "at %s",
+	AtSeconds:      "at %s seconds past the minute",
+	AtMinutes:      "at %s minutes past the hour",
+	SecondsRange:   "seconds %s through %s past the minute",
+	MinutesRange:   "minutes %s through %s past the hour",
+	HoursRange:     "between %s and %s",
+	DuringHours:    "during hours %s",
+	OnDay:          "on day %s of the month",
+	OnDays:         "on days %s of the month",
+	DaysRange:      "on days %s through %s of the month",
+	LastDay:        "on the last day of the month",
+	LastWeekday:    "on the last weekday of the month",
+	NearestWeekday: "on the weekday nearest day %s of the month",
+	OnWeekdays:     "on %s",
+	WeekdaysRange:  "%s through %s",
+	NthWeekday:     "on the %s %s of the month",
+	LastWeekdayOf:  "on the last %s of the month",
+	InMonths:       "in %s",
+	MonthsRange:    "%s through %s",
+	TimeZone:       "in %s time zone",
+	Separator:      ", ",
+	And:            " and ",
+	Or:             " or ",
+
+	Ordinals: [5]string{"first", "second", "third", "fourth", "fifth"},
+	Days: [7]string{
+		"Sunday", "Monday", "Tuesday", "Wednesday",
+		"Thursday", "Friday", "Saturday",
+	},
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December",
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	_MAX_TIMES_IN_DESC = 6 // Maximum number of exact times listed in description
+)
+
+const (
+	_KIND_ALL    uint8 = 0
+	_KIND_SINGLE uint8 = 1
+	_KIND_RANGE  uint8 = 2
+	_KIND_STEP   uint8 = 3
+	_KIND_LIST   uint8 = 4
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Describe returns human-readable description of expression (e.g. "every 15 minutes,
+// between 02:00 and 05:59, Monday through Friday"). By default, English locale
+// (LocaleEN) is used.
+func (e *Expr) Describe(locale ...*Locale) string {
+	if e == nil {
+		return ""
+	}
+
+	l := LocaleEN
+
+	if len(locale) != 0 && locale[0] != nil {
+		l = locale[0]
+	}
+
+	parts := e.describeTime(l)
+	days := e.describeDays(l)
+
+	if days != "" {
+		parts = append(parts, days)
+	}
+
+	if months := e.describeMonths(l); months != "" {
+		parts = append(parts, months)
+	}
+
+	if e.location != nil {
+		parts = append(parts, fmt.Sprintf(l.TimeZone, e.location.String()))
+	}
+
+	return strings.Join(parts, l.Separator)
+}
+
+// Upcoming returns iterator over next n matched moments after given date (current
+// date by default)
+func (e *Expr) Upcoming(n int, args ...time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if e == nil {
+			return
+		}
+
+		var t time.Time
+
+		if len(args) >= 1 {
+			t = args[0]
+		} else {
+			t = time.Now()
+		}
+
+		for range n {
+			t = e.Next(t)
+
+			if t.IsZero() || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// describeTime returns description of seconds, minutes and hours parts
+func (e *Expr) describeTime(l *Locale) []string {
+	var result []string
+
+	seconds := normalizeItems(e.seconds)
+	minutes := normalizeItems(e.minutes)
+	hours := normalizeItems(e.hours)
+
+	secKind, secStep := getItemsKind(seconds, info[0])
+	minKind, minStep := getItemsKind(minutes, info[1])
+	hourKind, hourStep := getItemsKind(hours, info[2])
+
+	if len(seconds) == 1 && len(minutes) == 1 &&
+		hourKind != _KIND_ALL && len(hours) <= _MAX_TIMES_IN_DESC {
+		var times []string
+
+		for _, h := range hours {
+			times = append(times, e.formatTime(h, minutes[0], seconds[0]))
+		}
+
+		return []string{fmt.Sprintf(l.AtTime, joinItems(times, l))}
+	}
+
+	withSeconds := e.withSeconds && !(len(seconds) == 1 && seconds[0] == 0)
+
+	if withSeconds {
+		result = append(result, describeField(
+			seconds, secKind, secStep, l.EverySecond, l.EveryNSeconds,
+			l.AtSeconds, l.SecondsRange, l,
+		))
+	}
+
+	if minKind != _KIND_ALL || !withSeconds {
+		result = append(result, describeField(
+			minutes, minKind, minStep, l.EveryMinute, l.EveryNMinutes,
+			l.AtMinutes, l.MinutesRange, l,
+		))
+	}
+
+	switch hourKind {
+	case _KIND_STEP:
+		result = append(result, fmt.Sprintf(l.EveryNHours, hourStep))
+	case _KIND_SINGLE, _KIND_RANGE:
+		result = append(result, fmt.Sprintf(
+			l.HoursRange,
+			fmt.Sprintf("%02d:00", hours[0]),
+			fmt.Sprintf("%02d:59", hours[len(hours)-1]),
+		))
+	case _KIND_LIST:
+		result = append(result, fmt.Sprintf(
+			l.DuringHours, joinItems(formatItems(hours, "%02d"), l),
+		))
+	}
+
+	return result
+}
+
+// describeDays returns description of day of month and day of week parts
+func (e *Expr) describeDays(l *Locale) string {
+	var doms, dows []string
+
+	if e.domExplicit {
+		doms = e.describeDOM(l)
+	}
+
+	if e.dowExplicit {
+		dows = e.describeDOW(l)
+	}
+
+	return strings.Join(append(doms, dows...), l.Or)
+}
+
+// describeDOM returns description of day of month part
+func (e *Expr) describeDOM(l *Locale) []string {
+	var result []string
+
+	doms := normalizeItems(e.doms)
+	kind, step := getItemsKind(doms, info[3])
+
+	switch {
+	case len(doms) == 0, kind == _KIND_ALL:
+		// nothing to describe
+	case kind == _KIND_SINGLE:
+		result = append(result, fmt.Sprintf(l.OnDay, strconv.Itoa(int(doms[0]))))
+	case kind == _KIND_RANGE:
+		result = append(result, fmt.Sprintf(
+			l.DaysRange,
+			strconv.Itoa(int(doms[0])), strconv.Itoa(int(doms[len(doms)-1])),
+		))
+	case kind == _KIND_STEP:
+		result = append(result, fmt.Sprintf(l.EveryNDays, step))
+	default:
+		result = append(result, fmt.Sprintf(
+			l.OnDays, joinItems(formatItems(doms, "%d"), l),
+		))
+	}
+
+	for _, d := range normalizeItems(e.domWeekdays) {
+		result = append(result, fmt.Sprintf(l.NearestWeekday, strconv.Itoa(int(d))))
+	}
+
+	if e.domLastWeekday {
+		result = append(result, l.LastWeekday)
+	}
+
+	if e.domLast {
+		result = append(result, l.LastDay)
+	}
+
+	return result
+}
+
+// describeDOW returns description of day of week part
+func (e *Expr) describeDOW(l *Locale) []string {
+	var result []string
+
+	dows := normalizeItems(e.dows)
+	kind, _ := getItemsKind(dows, info[5])
+
+	switch {
+	case len(dows) == 0, kind == _KIND_ALL:
+		// nothing to describe
+	case kind == _KIND_RANGE:
+		result = append(result, fmt.Sprintf(
+			l.WeekdaysRange,
+			getLocaleName(l.Days[:], int(dows[0])),
+			getLocaleName(l.Days[:], int(dows[len(dows)-1])),
+		))
+	default:
+		var names []string
+
+		for _, d := range dows {
+			names = append(names, getLocaleName(l.Days[:], int(d)))
+		}
+
+		result = append(result, fmt.Sprintf(l.OnWeekdays, joinItems(names, l)))
+	}
+
+	for _, d := range e.dowNths {
+		result = append(result, fmt.Sprintf(
+			l.NthWeekday,
+			getLocaleName(l.Ordinals[:], int(d.n)-1),
+			getLocaleName(l.Days[:], int(d.dow)),
+		))
+	}
+
+	for _, d := range normalizeItems(e.dowLasts) {
+		result = append(result, fmt.Sprintf(l.LastWeekdayOf, getLocaleName(l.Days[:], int(d))))
+	}
+
+	return result
+}
+
+// describeMonths returns description of months part
+func (e *Expr) describeMonths(l *Locale) string {
+	months := normalizeItems(e.months)
+	kind, step := getItemsKind(months, info[4])
+
+	switch {
+	case kind == _KIND_ALL:
+		return ""
+	case kind == _KIND_RANGE:
+		return fmt.Sprintf(
+			l.MonthsRange,
+			getLocaleName(l.Months[:], int(months[0])-1),
+			getLocaleName(l.Months[:], int(months[len(months)-1])-1),
+		)
+	case kind == _KIND_STEP:
+		return fmt.Sprintf(l.EveryNMonths, step)
+	}
+
+	var names []string
+
+	for _, m := range months {
+		names = append(names, getLocaleName(l.Months[:], int(m)-1))
+	}
+
+	return fmt.Sprintf(l.InMonths, joinItems(names, l))
+}
+
+// formatTime formats time of day
+func (e *Expr) formatTime(h, m, s uint8) string {
+	if e.withSeconds && s != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// describeField returns description of seconds or minutes part
+func describeField(items []uint8, kind, step uint8, every, everyN, at, period string, l *Locale) string {
+	switch kind {
+	case _KIND_ALL:
+		return every
+	case _KIND_STEP:
+		return fmt.Sprintf(everyN, step)
+	case _KIND_RANGE:
+		return fmt.Sprintf(
+			period,
+			strconv.Itoa(int(items[0])), strconv.Itoa(int(items[len(items)-1])),
+		)
+	}
+
+	return fmt.Sprintf(at, joinItems(formatItems(items, "%d"), l))
+}
+
+// getLocaleName returns name with given index from locale table or index as
+// a string if it is out of range
+func getLocaleName(names []string, index int) string {
+	if index < 0 || index >= len(names) {
+		return strconv.Itoa(index)
+	}
+
+	return names[index]
+}
+
+// getItemsKind returns kind of given sorted items and step for stepped items
+func getItemsKind(items []uint8, ei exprInfo) (uint8, uint8) {
+	switch {
+	case len(items) == int(ei.max-ei.min)+1:
+		return _KIND_ALL, 1
+	case len(items) == 1:
+		return _KIND_SINGLE, 0
+	case len(items) == 0:
+		return _KIND_LIST, 0
+	}
+
+	step := items[1] - items[0]
+
+	for i := 2; i < len(items); i++ {
+		if items[i]-items[i-1] != step {
+			return _KIND_LIST, 0
+		}
+	}
+
+	switch {
+	case step == 1:
+		return _KIND_RANGE, 1
+	case items[0] == ei.min && int(items[len(items)-1])+int(step) > int(ei.max):
+		return _KIND_STEP, step
+	}
+
+	return _KIND_LIST, 0
+}
+
+// normalizeItems returns sorted copy of items without duplicates
+func normalizeItems(items []uint8) []uint8 {
+	result := slices.Clone(items)
+
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
+
+// formatItems formats all items using given format
+func formatItems(items []uint8, format string) []string {
+	var result []string
+
+	for _, i := range items {
+		result = append(result, fmt.Sprintf(format, i))
+	}
+
+	return result
+}
+
+// joinItems joins items into enumeration (a, b and c)
+func joinItems(items []string, l *Locale) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+
+	return strings.Join(items[:len(items)-1], l.Separator) + l.And + items[len(items)-1]
+}
@@ -8,8 +8,11 @@ package cron
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"github.com/essentialkaos/ek/v14/events"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -32,6 +35,33 @@ func ExampleParse() {
 	// Execute2: false
 }
 
+func ExampleParse_extended() {
+	// At 10:00 on the weekday nearest to the 15th day of month
+	expr, err := Parse("0 10 15W * *")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%v\n", expr.Next(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Every 30 seconds during 09:00 on the last Friday of every month in
+	// Berlin time zone
+	expr, err = Parse("CRON_TZ=Europe/Berlin */30 0 9 ? * FriL")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%v\n", expr.Next(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Output:
+	// 2026-02-16 10:00:00 +0000 UTC
+	// 2026-02-27 09:00:00 +0100 CET
+}
+
 func ExampleExpr_Next() {
 	expr, err := Parse("0,15,30,45 0,6,12,18 1-10,15,31 * Mon-Fri")
 
@@ -74,3 +104,112 @@ func ExampleExpr_String() {
 	// Output:
 	// 0,15,30,45 0,6,12,18 1-10,15,31 * Mon-Fri
 }
+
+func ExampleExpr_Location() {
+	expr, err := Parse("CRON_TZ=Asia/Tokyo 0 9 * * Mon-Fri")
+
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("%s\n", expr.Location())
+
+	// Output:
+	// Asia/Tokyo
+}
+
+func ExampleExpr_Describe() {
+	expr, err := Parse("*/15 2-5 * * 1-5")
+
+	if err != nil {
+		return
+	}
+
+	fmt.Println(expr.Describe())
+
+	// Output:
+	// every 15 minutes, between 02:00 and 05:59, Monday through Friday
+}
+
+func ExampleExpr_Upcoming() {
+	expr, err := Parse("0 9 * * Mon-Fri")
+
+	if err != nil {
+		return
+	}
+
+	t := time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	for d := range expr.Upcoming(3, t) {
+		fmt.Println(d.Format("Mon 2006-01-02 15:04"))
+	}
+
+	// Output:
+	// Mon 2025-01-06 09:00
+	// Tue 2025-01-07 09:00
+	// Wed 2025-01-08 09:00
+}
+
+func ExampleNewScheduler() {
+	dispatcher := events.NewDispatcher()
+
+	dispatcher.AddHandler(EV_JOB_FAIL, func(payload any) {
+		ev := payload.(*JobEvent)
+		fmt.Printf("Job %s failed: %v\n", ev.Name, ev.Err)
+	})
+
+	scheduler := NewScheduler(dispatcher)
+	expr, _ := Parse("*/5 * * * *")
+
+	err := scheduler.Add(Job{
+		Name:    "cleanup",
+		Expr:    expr,
+		Overlap: OVERLAP_SKIP,
+		Jitter:  10 * time.Second,
+		Timeout: time.Minute,
+		Func: func(ctx context.Context) error {
+			// do some work
+			return nil
+		},
+	})
+
+	if err != nil {
+		fmt.Printf("Can't add job: %v\n", err)
+		return
+	}
+
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	for _, job := range scheduler.Jobs() {
+		fmt.Printf("Job %s will be executed at %s\n", job.Name, job.Next)
+	}
+}
+
+func ExampleScheduler_AddFunc() {
+	scheduler := NewScheduler(nil)
+
+	err := scheduler.AddFunc("report", "0 9 * * Mon-Fri", func(ctx context.Context) error {
+		// do some work
+		return nil
+	})
+
+	if err != nil {
+		fmt.Printf("Can't add job: %v\n", err)
+		return
+	}
+
+	scheduler.Start()
+	defer scheduler.Stop()
+}
+
+func ExampleScheduler_Jobs() {
+	scheduler := NewScheduler(nil)
+
+	scheduler.AddFunc("backup", "0 3 * * *", func(ctx context.Context) error { return nil })
+	scheduler.AddFunc("cleanup", "0 * * * *", func(ctx context.Context) error { return nil })
+
+	for _, job := range scheduler.Jobs() {
+		fmt.Printf("%s (%s) → %s\n", job.Name, job.Expr, job.Next)
+	}
+}
@@ -0,0 +1,469 @@
+package cron
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/events"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Overlap policies
+const (
+	OVERLAP_SKIP  uint8 = 0 // Skip run if previous run is still in progress
+	OVERLAP_QUEUE uint8 = 1 // Run job right after previous run is finished
+	OVERLAP_ALLOW uint8 = 2 // Run job concurrently with previous run
+)
+
+// Scheduler events
+const (
+	EV_JOB_START  = "job.start"  // Job execution started
+	EV_JOB_FINISH = "job.finish" // Job execution successfully finished
+	EV_JOB_FAIL   = "job.fail"   // Job execution failed
+	EV_JOB_SKIP   = "job.skip"   // Job execution skipped due to overlap
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// JobFunc is a function executed by scheduler
+type JobFunc func(ctx context.Context) error
+
+// Job contains job configuration
+type Job struct {
+	Name    string        // Unique job name
+	Expr    *Expr         // Cron expression
+	Func    JobFunc       // Job function
+	Overlap uint8         // Overlap policy (OVERLAP_SKIP, OVERLAP_QUEUE, OVERLAP_ALLOW)
+	Jitter  time.Duration // Maximum random delay before execution
+	Timeout time.Duration // Maximum duration of execution (0 = no limit)
+}
+
+// JobInfo contains info about scheduled job
+type JobInfo struct {
+	Name    string    // Job name
+	Expr    *Expr     // Cron expression
+	Next    time.Time // Date of next run
+	Last    time.Time // Date of last run start
+	Running int       // Number of running instances
+}
+
+// JobEvent is payload of scheduler events
+type JobEvent struct {
+	Name     string        // Job name
+	Start    time.Time     // Date of execution start
+	Duration time.Duration // Duration of execution
+	Err      error         // Execution error
+}
+
+// Scheduler is in-process job scheduler
+type Scheduler struct {
+	dispatcher *events.Dispatcher
+	jobs       map[string]*jobState
+	wakeChan   chan struct{}
+	cancel     context.CancelFunc
+	loopWG     sync.WaitGroup
+	jobsWG     sync.WaitGroup
+	mu         sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// jobState contains job configuration and execution state
+type jobState struct {
+	job     Job
+	next    time.Time
+	last    time.Time
+	running int
+	queued  int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrNilScheduler is returned if scheduler struct is nil
+	ErrNilScheduler = errors.New("scheduler is nil")
+
+	// ErrEmptyJobName is returned if job name is empty
+	ErrEmptyJobName = errors.New("job name is empty")
+
+	// ErrNilJobExpr is returned if job expression is nil
+	ErrNilJobExpr = errors.New("job expression is nil")
+
+	// ErrNilJobFunc is returned if job function is nil
+	ErrNilJobFunc = errors.New("job function is nil")
+
+	// ErrUnknownOverlap is returned if job has unsupported overlap policy
+	ErrUnknownOverlap = errors.New("unknown overlap policy")
+
+	// ErrSchedulerStarted is returned by Start if scheduler is already started
+	ErrSchedulerStarted = errors.New("scheduler is already started")
+
+	// ErrSchedulerStopped is returned by Stop if scheduler is not started
+	ErrSchedulerStopped = errors.New("scheduler is not started")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewScheduler creates new scheduler. Dispatcher is optional and used for sending
+// job events (EV_JOB_START, EV_JOB_FINISH, EV_JOB_FAIL, EV_JOB_SKIP). Events are
+// dispatched asynchronously, so slow handlers don't delay jobs.
+func NewScheduler(dispatcher *events.Dispatcher) *Scheduler {
+	return &Scheduler{
+		dispatcher: dispatcher,
+		jobs:       make(map[string]*jobState),
+		wakeChan:   make(chan struct{}, 1),
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Add adds new job to scheduler
+func (s *Scheduler) Add(job Job) error {
+	switch {
+	case s == nil || s.jobs == nil:
+		return ErrNilScheduler
+	case job.Name == "":
+		return ErrEmptyJobName
+	case job.Expr == nil:
+		return ErrNilJobExpr
+	case job.Func == nil:
+		return ErrNilJobFunc
+	case job.Overlap > OVERLAP_ALLOW:
+		return ErrUnknownOverlap
+	}
+
+	s.mu.Lock()
+
+	if s.jobs[job.Name] != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("job %q already exists", job.Name)
+	}
+
+	s.jobs[job.Name] = &jobState{job: job, next: job.Expr.Next()}
+	s.mu.Unlock()
+
+	s.wake()
+
+	return nil
+}
+
+// AddFunc parses given expression and adds new job with default settings
+func (s *Scheduler) AddFunc(name, expr string, fn JobFunc) error {
+	if s == nil || s.jobs == nil {
+		return ErrNilScheduler
+	}
+
+	e, err := Parse(expr)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Add(Job{Name: name, Expr: e, Func: fn})
+}
+
+// Remove removes job with given name. Running instances of the job are not
+// interrupted.
+func (s *Scheduler) Remove(name string) bool {
+	if s == nil || s.jobs == nil {
+		return false
+	}
+
+	s.mu.Lock()
+
+	j := s.jobs[name]
+
+	if j != nil {
+		j.queued = 0
+		delete(s.jobs, name)
+	}
+
+	s.mu.Unlock()
+
+	if j != nil {
+		s.wake()
+	}
+
+	return j != nil
+}
+
+// Jobs returns info about all scheduled jobs sorted by date of next run
+func (s *Scheduler) Jobs() []JobInfo {
+	if s == nil || s.jobs == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+
+	result := make([]JobInfo, 0, len(s.jobs))
+
+	for _, j := range s.jobs {
+		result = append(result, JobInfo{
+			Name:    j.job.Name,
+			Expr:    j.job.Expr,
+			Next:    j.next,
+			Last:    j.last,
+			Running: j.running,
+		})
+	}
+
+	s.mu.Unlock()
+
+	slices.SortFunc(result, func(a, b JobInfo) int {
+		if c := a.Next.Compare(b.Next); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
+
+// Start starts scheduler
+func (s *Scheduler) Start() error {
+	if s == nil || s.jobs == nil {
+		return ErrNilScheduler
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return ErrSchedulerStarted
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	for _, j := range s.jobs {
+		j.next = j.job.Expr.Next()
+	}
+
+	s.cancel = cancel
+	s.loopWG.Add(1)
+
+	go s.loop(ctx)
+
+	return nil
+}
+
+// Stop stops scheduler, cancels context of all running jobs and waits until
+// they are finished
+func (s *Scheduler) Stop() error {
+	if s == nil || s.jobs == nil {
+		return ErrNilScheduler
+	}
+
+	s.mu.Lock()
+
+	if s.cancel == nil {
+		s.mu.Unlock()
+		return ErrSchedulerStopped
+	}
+
+	s.cancel()
+	s.cancel = nil
+
+	for _, j := range s.jobs {
+		j.queued = 0
+	}
+
+	s.mu.Unlock()
+
+	s.loopWG.Wait()
+	s.jobsWG.Wait()
+
+	return nil
+}
+
+// IsRunning returns true if scheduler is started
+func (s *Scheduler) IsRunning() bool {
+	if s == nil || s.jobs == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cancel != nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// loop is scheduler main loop
+func (s *Scheduler) loop(ctx context.Context) {
+	defer s.loopWG.Done()
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		timer.Reset(s.runDueJobs(ctx, time.Now()))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wakeChan:
+		case <-timer.C:
+		}
+	}
+}
+
+// runDueJobs runs all jobs which must be executed and returns duration until
+// the next run
+func (s *Scheduler) runDueJobs(ctx context.Context, now time.Time) time.Duration {
+	var nearest time.Time
+	var skipped []string
+
+	s.mu.Lock()
+
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		return time.Hour
+	}
+
+	for _, j := range s.jobs {
+		if !j.next.IsZero() && !j.next.After(now) {
+			if !s.runJob(ctx, j) {
+				skipped = append(skipped, j.job.Name)
+			}
+
+			j.next = j.job.Expr.Next(now)
+		}
+
+		if !j.next.IsZero() && (nearest.IsZero() || j.next.Before(nearest)) {
+			nearest = j.next
+		}
+	}
+
+	s.mu.Unlock()
+
+	for _, name := range skipped {
+		s.dispatch(EV_JOB_SKIP, &JobEvent{Name: name, Start: now})
+	}
+
+	if nearest.IsZero() {
+		return time.Hour
+	}
+
+	return max(time.Until(nearest), 0)
+}
+
+// runJob runs job with respect to its overlap policy. It returns false if run
+// was skipped. Must be called under lock.
+func (s *Scheduler) runJob(ctx context.Context, j *jobState) bool {
+	if j.running > 0 {
+		switch j.job.Overlap {
+		case OVERLAP_SKIP:
+			return false
+		case OVERLAP_QUEUE:
+			j.queued++
+			return true
+		}
+	}
+
+	j.running++
+	s.jobsWG.Add(1)
+
+	go s.execJob(ctx, j)
+
+	return true
+}
+
+// execJob executes job and all queued runs
+func (s *Scheduler) execJob(ctx context.Context, j *jobState) {
+	defer s.jobsWG.Done()
+
+	for {
+		if j.job.Jitter > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(rand.N(j.job.Jitter)):
+			}
+		}
+
+		if ctx.Err() == nil {
+			s.mu.Lock()
+			j.last = time.Now()
+			s.mu.Unlock()
+
+			s.execJobFunc(ctx, j.job)
+		}
+
+		s.mu.Lock()
+
+		if j.queued == 0 || ctx.Err() != nil {
+			j.running--
+			s.mu.Unlock()
+			return
+		}
+
+		j.queued--
+		s.mu.Unlock()
+	}
+}
+
+// execJobFunc executes job function and sends events
+func (s *Scheduler) execJobFunc(ctx context.Context, job Job) {
+	ev := &JobEvent{Name: job.Name, Start: time.Now()}
+
+	s.dispatch(EV_JOB_START, ev)
+
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	ev = &JobEvent{Name: job.Name, Start: ev.Start}
+	ev.Err = callJobFunc(ctx, job.Func)
+	ev.Duration = time.Since(ev.Start)
+
+	if ev.Err != nil {
+		s.dispatch(EV_JOB_FAIL, ev)
+	} else {
+		s.dispatch(EV_JOB_FINISH, ev)
+	}
+}
+
+// dispatch asynchronously sends event to dispatcher if it set
+func (s *Scheduler) dispatch(ev string, payload *JobEvent) {
+	if s.dispatcher != nil {
+		s.dispatcher.Dispatch(ev, payload)
+	}
+}
+
+// wake wakes up scheduler main loop
+func (s *Scheduler) wake() {
+	select {
+	case s.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// callJobFunc calls job function and converts panic to error
+func callJobFunc(ctx context.Context, fn JobFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return fn(ctx)
+}
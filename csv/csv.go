@@ -12,9 +12,11 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -29,9 +31,12 @@ type Reader struct {
 	headerSkipped bool
 	headerToLower bool
 	headerToUpper bool
+	strict        bool
 	currentLine   int
 
-	s *bufio.Scanner
+	s       *bufio.Scanner
+	err     error
+	decoder *decoder
 }
 
 // Row is CSV row
@@ -40,6 +45,13 @@ type Row []string
 // Header is row with header data
 type Header []string
 
+// ParseError is returned by reader in RFC 4180 mode if data is malformed
+type ParseError struct {
+	Line   int   // Line where error occurred
+	Column int   // Column (1-based, in runes) where error occurred
+	Err    error // Actual error
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var (
@@ -54,6 +66,17 @@ var (
 
 	// ErrNilMap is returned when map is nil
 	ErrNilMap = errors.New("map is nil")
+
+	// ErrBareQuote is returned in RFC 4180 mode if unquoted field contains quote
+	ErrBareQuote = errors.New("bare \" in non-quoted field")
+
+	// ErrExtraneousQuote is returned in RFC 4180 mode if quoted field contains
+	// unescaped quote
+	ErrExtraneousQuote = errors.New("extraneous \" in quoted field")
+
+	// ErrUnclosedQuote is returned in RFC 4180 mode if quoted field is not closed
+	// before the end of data
+	ErrUnclosedQuote = errors.New("quoted field is not closed")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -80,6 +103,10 @@ func (r *Reader) Read() (Row, error) {
 		return nil, ErrNilReader
 	}
 
+	if r.err != nil {
+		return nil, r.err
+	}
+
 	if r.hasHeader && !r.headerSkipped {
 		err := r.readHeader()
 
@@ -88,13 +115,7 @@ func (r *Reader) Read() (Row, error) {
 		}
 	}
 
-	if !r.s.Scan() {
-		return nil, r.getError()
-	}
-
-	r.currentLine++
-
-	return strings.Split(r.s.Text(), r.comma), nil
+	return r.readRow()
 }
 
 // ReadTo reads data to given slice
@@ -106,6 +127,10 @@ func (r *Reader) ReadTo(dst Row) error {
 		return ErrEmptyDest
 	}
 
+	if r.err != nil {
+		return r.err
+	}
+
 	if r.hasHeader && !r.headerSkipped {
 		err := r.readHeader()
 
@@ -114,6 +139,18 @@ func (r *Reader) ReadTo(dst Row) error {
 		}
 	}
 
+	if r.strict {
+		row, err := r.readRow()
+
+		if err != nil {
+			return err
+		}
+
+		clean(dst, copy(dst, row))
+
+		return nil
+	}
+
 	if !r.s.Scan() {
 		return r.getError()
 	}
@@ -183,6 +220,19 @@ func (r *Reader) WithHeaderToUpper(flag bool) *Reader {
 	return r
 }
 
+// WithRFC4180 configures the reader to parse data according to RFC 4180. In this
+// mode, fields can be enclosed in double quotes and contain separators, escaped
+// double quotes ("") and line breaks. Malformed data is reported as [ParseError].
+func (r *Reader) WithRFC4180(flag bool) *Reader {
+	if r == nil || r.s == nil {
+		return nil
+	}
+
+	r.strict = flag
+
+	return r
+}
+
 // WithBufferSize sets the maximum token size for the underlying scanner
 func (r *Reader) WithBufferSize(n int) *Reader {
 	if r == nil || r.s == nil {
@@ -203,12 +253,16 @@ func (r *Reader) Line() int {
 	return r.currentLine
 }
 
-// Error returns error from underlying scanner
+// Error returns parsing error or error from underlying scanner
 func (r *Reader) Error() error {
 	if r == nil || r.s == nil {
 		return nil
 	}
 
+	if r.err != nil {
+		return r.err
+	}
+
 	return r.s.Err()
 }
 
@@ -426,6 +480,18 @@ func (h Header) Map(m map[string]string, r Row) error {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// Error returns error message with position
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns actual error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // getError returns error from scanner
 func (r *Reader) getError() error {
 	if r.s.Err() == nil {
@@ -437,14 +503,14 @@ func (r *Reader) getError() error {
 
 // readHeader reads header data
 func (r *Reader) readHeader() error {
-	if !r.s.Scan() {
-		return r.getError()
+	row, err := r.readRow()
+
+	if err != nil {
+		return err
 	}
 
 	r.headerSkipped = true
-	r.currentLine++
-
-	r.Header = strings.Split(r.s.Text(), r.comma)
+	r.Header = Header(row)
 
 	for i, h := range r.Header {
 		switch {
@@ -458,6 +524,106 @@ func (r *Reader) readHeader() error {
 	return nil
 }
 
+// readRow reads and parses next row
+func (r *Reader) readRow() (Row, error) {
+	if !r.s.Scan() {
+		return nil, r.getError()
+	}
+
+	r.currentLine++
+
+	if !r.strict {
+		return strings.Split(r.s.Text(), r.comma), nil
+	}
+
+	row, err := r.parseQuotedRow(r.s.Text())
+
+	if err != nil {
+		r.err = err
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// parseQuotedRow parses row according to RFC 4180. Quoted fields with line
+// breaks are read from following lines.
+func (r *Reader) parseQuotedRow(line string) (Row, error) {
+	var row Row
+	var field strings.Builder
+
+	pos := 0
+
+	for {
+		if !strings.HasPrefix(line[pos:], `"`) {
+			value, _, found := strings.Cut(line[pos:], r.comma)
+
+			if i := strings.IndexByte(value, '"'); i != -1 {
+				return nil, r.parseError(line, pos+i, ErrBareQuote)
+			}
+
+			row = append(row, value)
+
+			if !found {
+				return row, nil
+			}
+
+			pos += len(value) + len(r.comma)
+			continue
+		}
+
+		startLine, startColumn := r.currentLine, getColumn(line, pos)
+
+		field.Reset()
+		pos++
+
+	QUOTED:
+		for {
+			i := strings.IndexByte(line[pos:], '"')
+
+			if i == -1 {
+				field.WriteString(line[pos:])
+
+				if !r.s.Scan() {
+					if r.s.Err() != nil {
+						return nil, r.s.Err()
+					}
+
+					return nil, &ParseError{startLine, startColumn, ErrUnclosedQuote}
+				}
+
+				r.currentLine++
+				field.WriteByte('\n')
+				line, pos = r.s.Text(), 0
+
+				continue
+			}
+
+			field.WriteString(line[pos : pos+i])
+			pos += i + 1
+
+			switch {
+			case strings.HasPrefix(line[pos:], `"`):
+				field.WriteByte('"')
+				pos++
+			case pos == len(line):
+				return append(row, field.String()), nil
+			case strings.HasPrefix(line[pos:], r.comma):
+				row = append(row, field.String())
+				pos += len(r.comma)
+				break QUOTED
+			default:
+				return nil, r.parseError(line, pos-1, ErrExtraneousQuote)
+			}
+		}
+	}
+}
+
+// parseError creates parse error for given position in current line
+func (r *Reader) parseError(line string, pos int, err error) error {
+	return &ParseError{r.currentLine, getColumn(line, pos), err}
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // writeRow returns buffer with row data
@@ -509,6 +675,11 @@ func parseAndFill(src string, dst Row, sep string) {
 	}
 }
 
+// getColumn returns 1-based column number (in runes) for given byte offset
+func getColumn(line string, pos int) int {
+	return utf8.RuneCountInString(line[:pos]) + 1
+}
+
 // clean cleans destination slice
 func clean(dst Row, from int) {
 	for i := from; i < len(dst); i++ {
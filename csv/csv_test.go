@@ -8,12 +8,19 @@ package csv
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/essentialkaos/check"
+
+	ekerrors "github.com/essentialkaos/ek/v14/errors"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -191,6 +198,298 @@ func (s *CSVSuite) TestReadErrors(c *C) {
 	c.Assert(h.Map(m, rr), Equals, ErrNilMap)
 }
 
+func (s *CSVSuite) TestRFC4180(c *C) {
+	data := "id,name,comment\r\n" +
+		"1,\"Doe, John\",\"He said \"\"Hi\"\"\"\r\n" +
+		"2,\"multi\r\nline\r\nvalue\",\"\"\r\n" +
+		"3,,\"\"\"\"\r\n" +
+		"\"4\",Ünïcode,last,\r\n"
+
+	r := NewReader(strings.NewReader(data), ',').WithRFC4180(true).WithHeader(true)
+
+	row, err := r.Read()
+	c.Assert(err, IsNil)
+	c.Assert(r.Header, DeepEquals, Header{"id", "name", "comment"})
+	c.Assert(row, DeepEquals, Row{"1", "Doe, John", `He said "Hi"`})
+	c.Assert(r.Line(), Equals, 2)
+
+	row, err = r.Read()
+	c.Assert(err, IsNil)
+	c.Assert(row, DeepEquals, Row{"2", "multi\nline\nvalue", ""})
+	c.Assert(r.Line(), Equals, 5)
+
+	dst := Row{"", "", "", "", "", ""}
+	c.Assert(r.ReadTo(dst), IsNil)
+	c.Assert(dst, DeepEquals, Row{"3", "", `"`, "", "", ""})
+
+	row, err = r.Read()
+	c.Assert(err, IsNil)
+	c.Assert(row, DeepEquals, Row{"4", "Ünïcode", "last", ""})
+
+	_, err = r.Read()
+	c.Assert(err, Equals, io.EOF)
+	c.Assert(r.Error(), IsNil)
+
+	r = NewReader(strings.NewReader("A;\"B;C\";D"), ';').WithRFC4180(true)
+	row, err = r.Read()
+	c.Assert(err, IsNil)
+	c.Assert(row, DeepEquals, Row{"A", "B;C", "D"})
+
+	r = NewReader(strings.NewReader("A|\"B|C\"|D"), '|').WithRFC4180(false)
+	row, err = r.Read()
+	c.Assert(err, IsNil)
+	c.Assert(row, DeepEquals, Row{"A", `"B`, `C"`, "D"})
+}
+
+func (s *CSVSuite) TestRFC4180Errors(c *C) {
+	cases := []struct {
+		data   string
+		line   int
+		column int
+		err    error
+	}{
+		{"1,2\n3,a\"b,4\n", 2, 4, ErrBareQuote},
+		{"1,2\nЖ,\"ab\"c,4\n", 2, 6, ErrExtraneousQuote},
+		{"1,2\n3,4\n5,\"abc\n\ndef\n", 3, 3, ErrUnclosedQuote},
+	}
+
+	for _, cs := range cases {
+		r := NewReader(strings.NewReader(cs.data), ',').WithRFC4180(true)
+
+		var rows int
+
+		for range r.Seq {
+			rows++
+		}
+
+		c.Assert(rows, Equals, cs.line-1)
+
+		err := r.Error()
+		c.Assert(err, NotNil)
+
+		var pe *ParseError
+
+		c.Assert(errors.As(err, &pe), Equals, true)
+		c.Assert(pe.Line, Equals, cs.line)
+		c.Assert(pe.Column, Equals, cs.column)
+		c.Assert(errors.Is(err, cs.err), Equals, true)
+		c.Assert(err.Error(), Equals, fmt.Sprintf("line %d, column %d: %v", cs.line, cs.column, cs.err))
+
+		_, err = r.Read()
+		c.Assert(err, Equals, pe)
+		c.Assert(r.ReadTo(Row{""}), Equals, pe)
+	}
+
+	r := NewReader(strings.NewReader("\"a"), ',').WithRFC4180(true).WithHeader(true)
+	_, err := r.Read()
+	c.Assert(err, ErrorMatches, `line 1, column 1: quoted field is not closed`)
+}
+
+func (s *CSVSuite) TestDecode(c *C) {
+	type Level uint8
+
+	type Base struct {
+		Comment string `csv:"comment"`
+	}
+
+	type User struct {
+		Base
+
+		ID       int           `csv:"id,required"`
+		Name     string        `csv:"name,required"`
+		Balance  float64       `csv:"balance"`
+		Rate     float32       `csv:"rate"`
+		Age      uint8         `csv:"age"`
+		Active   bool          `csv:"active"`
+		Created  time.Time     `csv:"created" layout:"2006-01-02"`
+		Updated  *time.Time    `csv:"updated"`
+		Timeout  time.Duration `csv:"timeout"`
+		Score    *int64        `csv:"score"`
+		Level    Level         `csv:"level"`
+		IP       net.IP        `csv:"ip"`
+		Missing  string        `csv:"missing"`
+		Ignored  string        `csv:"-"`
+		Nickname string
+
+		private string
+	}
+
+	data := "ID;Name;Balance;Rate;Age;Active;Created;Updated;Timeout;Score;Level;IP;Nickname;Comment\n" +
+		"1;John;10.5;0.5;32;yes;2025-01-02;2025-01-02T10:00:00Z;1m;-100;3;127.0.0.1;jd;Test\n" +
+		"2;Jane;;;;;;;;;;;;\n"
+
+	r := NewReader(strings.NewReader(data), ';').WithHeader(true).WithHeaderToLower(true)
+
+	u := &User{Ignored: "ignored", private: "private"}
+
+	c.Assert(r.Decode(u), IsNil)
+	c.Assert(u.ID, Equals, 1)
+	c.Assert(u.Name, Equals, "John")
+	c.Assert(u.Balance, Equals, 10.5)
+	c.Assert(u.Rate, Equals, float32(0.5))
+	c.Assert(u.Age, Equals, uint8(32))
+	c.Assert(u.Active, Equals, true)
+	c.Assert(u.Created.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(u.Updated, NotNil)
+	c.Assert(u.Updated.Equal(time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(u.Timeout, Equals, time.Minute)
+	c.Assert(u.Score, NotNil)
+	c.Assert(*u.Score, Equals, int64(-100))
+	c.Assert(u.Level, Equals, Level(3))
+	c.Assert(u.IP.String(), Equals, "127.0.0.1")
+	c.Assert(u.Nickname, Equals, "jd")
+	c.Assert(u.Comment, Equals, "Test")
+	c.Assert(u.Ignored, Equals, "ignored")
+	c.Assert(u.private, Equals, "private")
+
+	u = &User{}
+
+	c.Assert(r.Decode(u), IsNil)
+	c.Assert(u.ID, Equals, 2)
+	c.Assert(u.Name, Equals, "Jane")
+	c.Assert(u.Updated, IsNil)
+	c.Assert(u.Score, IsNil)
+	c.Assert(u.Created.IsZero(), Equals, true)
+
+	c.Assert(r.Decode(u), Equals, io.EOF)
+}
+
+func (s *CSVSuite) TestDecodeEmbeddedPointer(c *C) {
+	type Meta struct {
+		Comment string `csv:"comment"`
+	}
+
+	type Info struct {
+		Tag string `csv:"tag"`
+	}
+
+	type Item struct {
+		*Meta
+		*Info
+
+		ID int `csv:"id"`
+	}
+
+	type hidden struct {
+		Secret string `csv:"secret"`
+	}
+
+	type Record struct {
+		*hidden
+
+		ID int `csv:"id"`
+	}
+
+	data := "id,comment,tag,secret\n" +
+		"1,Test,,abc\n"
+
+	r := NewReader(strings.NewReader(data), ',').WithHeader(true)
+	item := &Item{}
+
+	c.Assert(r.Decode(item), IsNil)
+	c.Assert(item.ID, Equals, 1)
+	c.Assert(item.Meta, NotNil)
+	c.Assert(item.Comment, Equals, "Test")
+	c.Assert(item.Info, IsNil)
+
+	r = NewReader(strings.NewReader(data), ',').WithHeader(true)
+	rec := &Record{}
+
+	c.Assert(r.Decode(rec), IsNil)
+	c.Assert(rec.ID, Equals, 1)
+	c.Assert(rec.hidden, IsNil)
+}
+
+func (s *CSVSuite) TestDecodeAll(c *C) {
+	type Item struct {
+		ID    uint16  `csv:"id,required"`
+		Name  string  `csv:"name,required"`
+		Price float64 `csv:"price"`
+		Count int8    `csv:"count"`
+	}
+
+	data := "id,name,price,count\n" +
+		"1,Apple,1.5,10\n" +
+		"2,,abc,1000\n" +
+		"3,Orange,,\n" +
+		"-4,Lemon,2,1\n"
+
+	var items []Item
+
+	r := NewReader(strings.NewReader(data), ',').WithHeader(true)
+	err := r.DecodeAll(&items)
+
+	c.Assert(err, NotNil)
+	c.Assert(items, DeepEquals, []Item{{1, "Apple", 1.5, 10}, {3, "Orange", 0, 0}})
+
+	errs, ok := err.(*ekerrors.Bundle)
+
+	c.Assert(ok, Equals, true)
+	c.Assert(errs.Num(), Equals, 4)
+	c.Assert(errs.Get(0), ErrorMatches, `line 3, column "name": required cell is empty`)
+	c.Assert(errs.Get(1), ErrorMatches, `line 3, column "price": strconv.ParseFloat: parsing "abc": invalid syntax`)
+	c.Assert(errs.Get(2), ErrorMatches, `line 3, column "count": strconv.ParseInt: parsing "1000": value out of range`)
+	c.Assert(errs.Get(3), ErrorMatches, `line 5, column "id": .*`)
+
+	var de *DecodeError
+
+	c.Assert(errors.As(errs.First(), &de), Equals, true)
+	c.Assert(de.Line, Equals, 3)
+	c.Assert(de.Column, Equals, "name")
+	c.Assert(errors.Is(de, ErrEmptyCell), Equals, true)
+
+	var ptrItems []*Item
+
+	r = NewReader(strings.NewReader(data), ',').WithHeader(true)
+	c.Assert(r.DecodeAll(&ptrItems), NotNil)
+	c.Assert(ptrItems, HasLen, 2)
+	c.Assert(ptrItems[1].Name, Equals, "Orange")
+}
+
+func (s *CSVSuite) TestDecodeErrors(c *C) {
+	type Item struct {
+		ID   int    `csv:"id,required"`
+		Name string `csv:"name,required"`
+	}
+
+	type BadItem struct {
+		Data []string `csv:"data"`
+	}
+
+	var item Item
+	var items []Item
+
+	r := NewReader(strings.NewReader("1,Test\n"), ',')
+	c.Assert(r.Decode(&item), Equals, ErrEmptyHeader)
+
+	r = NewReader(strings.NewReader("id,title\n1,Test\n"), ',').WithHeader(true)
+	c.Assert(r.Decode(&item), ErrorMatches, `required column is missing: "name"`)
+
+	r = NewReader(strings.NewReader("id,title\n1,Test\n"), ',').WithHeader(true)
+	c.Assert(r.DecodeAll(&items), ErrorMatches, `required column is missing: "name"`)
+
+	r = NewReader(strings.NewReader("data\n1\n"), ',').WithHeader(true)
+	c.Assert(r.Decode(&BadItem{}), ErrorMatches, `unsupported field type \[\]string of field "Data"`)
+
+	r = NewReader(strings.NewReader("id,name\n\"1,Test\n"), ',').WithHeader(true).WithRFC4180(true)
+	c.Assert(r.DecodeAll(&items), ErrorMatches, `line 2, column 1: quoted field is not closed`)
+
+	r = NewReader(strings.NewReader("id,name\n"), ',').WithHeader(true)
+	c.Assert(r.DecodeAll(&items), IsNil)
+
+	c.Assert(r.Decode(nil), Equals, ErrInvalidTarget)
+	c.Assert(r.Decode(item), Equals, ErrInvalidTarget)
+	c.Assert(r.Decode(&items), Equals, ErrInvalidTarget)
+	c.Assert(r.DecodeAll(nil), Equals, ErrInvalidTarget)
+	c.Assert(r.DecodeAll(&item), Equals, ErrInvalidTarget)
+	c.Assert(r.DecodeAll(&[]string{}), Equals, ErrInvalidTarget)
+
+	var nr *Reader
+
+	c.Assert(nr.Decode(&item), Equals, ErrNilReader)
+	c.Assert(nr.DecodeAll(&items), Equals, ErrNilReader)
+}
+
 func (s *CSVSuite) TestLineParser(c *C) {
 	row := make(Row, 2)
 
@@ -225,6 +524,7 @@ func (s *CSVSuite) TestNil(c *C) {
 	c.Assert(r.Line(), Equals, 0)
 	c.Assert(r.Error(), IsNil)
 	c.Assert(r.WithBufferSize(100), IsNil)
+	c.Assert(r.WithRFC4180(true), IsNil)
 
 	r = NewReader(nil, ',')
 	c.Assert(r, IsNil)
@@ -315,6 +615,81 @@ func (s *CSVSuite) TestRow(c *C) {
 	c.Assert(err, NotNil)
 }
 
+func (s *CSVSuite) TestWriter(c *C) {
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf, ',')
+
+	c.Assert(w.WriteHeader(Header{"ID", "Name", "Comment"}), IsNil)
+	c.Assert(w.Write(Row{"1", "John Doe", "simple"}), IsNil)
+	c.Assert(w.Write(Row{"2", "Doe, John", `"quoted" text`}), IsNil)
+	c.Assert(w.Write(Row{"3", "", "multi\nline"}), IsNil)
+	c.Assert(w.Write(Row{"4", " leading space", "\r"}), IsNil)
+	c.Assert(w.Write(Row{}), IsNil)
+	c.Assert(buf.Len(), Equals, 0)
+	c.Assert(w.Flush(), IsNil)
+
+	c.Assert(buf.String(), Equals, `ID,Name,Comment
+1,John Doe,simple
+2,"Doe, John","""quoted"" text"
+3,,"multi
+line"
+4," leading space","`+"\r"+`"
+
+`)
+
+	buf.Reset()
+	w = NewWriter(&buf, ';').WithCRLF(true).WithBufferSize(16)
+
+	c.Assert(w.WriteAll([]Row{{"A;B", "C"}, {"D,E", "F"}}), IsNil)
+	c.Assert(buf.String(), Equals, "\"A;B\";C\r\nD,E;F\r\n")
+
+	w = NewWriter(&buf, '"')
+	c.Assert(w.Write(Row{"A"}), Equals, ErrInvalidComma)
+	c.Assert(w.WriteAll([]Row{{"A"}}), Equals, ErrInvalidComma)
+}
+
+func (s *CSVSuite) TestWriterValues(c *C) {
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf, ',')
+	d := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	c.Assert(w.WriteValues(
+		nil, "test", []byte("data"), true, false,
+		int(-1), int8(-2), int16(-3), int32(-4), int64(-5),
+		uint(1), uint8(2), uint16(3), uint32(4), uint64(5),
+		float32(1.5), float64(3.14159), d, 90*time.Second,
+		errors.New("error, text"), struct{ A int }{1}, time.Time{},
+	), IsNil)
+
+	w.WithTimeLayout("2006-01-02")
+
+	c.Assert(w.WriteValues(d, 1.0), IsNil)
+	c.Assert(w.Flush(), IsNil)
+
+	c.Assert(buf.String(), Equals,
+		`,test,data,true,false,-1,-2,-3,-4,-5,1,2,3,4,5,1.5,3.14159,2025-03-14T15:09:26Z,1m30s,"error, text",{1},`+"\n"+
+			"2025-03-14,1\n",
+	)
+
+	c.Assert(w.formatValue(fmt.Stringer(time.March)), Equals, "March")
+}
+
+func (s *CSVSuite) TestWriterNil(c *C) {
+	var w *Writer
+
+	c.Assert(NewWriter(nil, ','), IsNil)
+	c.Assert(w.WithCRLF(true), IsNil)
+	c.Assert(w.WithTimeLayout(""), IsNil)
+	c.Assert(w.WithBufferSize(10), IsNil)
+	c.Assert(w.WriteHeader(Header{"A"}), Equals, ErrNilWriter)
+	c.Assert(w.Write(Row{"A"}), Equals, ErrNilWriter)
+	c.Assert(w.WriteValues(1), Equals, ErrNilWriter)
+	c.Assert(w.WriteAll([]Row{{"A"}}), Equals, ErrNilWriter)
+	c.Assert(w.Flush(), Equals, ErrNilWriter)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 func (s *CSVSuite) BenchmarkRead(c *C) {
@@ -0,0 +1,452 @@
+package csv
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/errors"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DecodeError contains info about error occurred while decoding cell value
+type DecodeError struct {
+	Line   int    // Line with malformed data
+	Column string // Column name
+	Err    error  // Actual error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// fieldInfo contains info about struct field mapped to column
+type fieldInfo struct {
+	index    []int
+	column   int
+	name     string
+	layout   string
+	required bool
+}
+
+// decoder contains cached mapping of struct fields to columns
+type decoder struct {
+	typ    reflect.Type
+	fields []fieldInfo
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrInvalidTarget is returned by Decode and DecodeAll methods if target has
+	// wrong type
+	ErrInvalidTarget = errors.New("target must be a non-nil pointer to struct or slice of structs")
+
+	// ErrMissingColumn is returned if required column is not present in header
+	ErrMissingColumn = errors.New("required column is missing")
+
+	// ErrEmptyCell is returned if required cell is empty
+	ErrEmptyCell = errors.New("required cell is empty")
+
+	// ErrUnsupportedType is returned if struct field has unsupported type
+	ErrUnsupportedType = errors.New("unsupported field type")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Decode reads next row and decodes it into struct pointed by v. Reader must be
+// configured to read header (see [Reader.WithHeader]).
+//
+// Struct fields are mapped to columns using "csv" tag ("name", "name,required" or
+// "-" for ignoring field). Fields without tag are mapped using field name. Layout
+// for time.Time fields can be set using "layout" tag (time.RFC3339 by default).
+//
+// If some cells contain malformed data, Decode returns [errors.Bundle] with
+// [DecodeError] for every malformed cell.
+func (r *Reader) Decode(v any) error {
+	if r == nil || r.s == nil {
+		return ErrNilReader
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	row, err := r.Read()
+
+	if err != nil {
+		return err
+	}
+
+	return r.decodeRow(row, rv.Elem())
+}
+
+// DecodeAll reads all remaining rows and appends them to slice of structs (or
+// pointers to structs) pointed by v. Rows with malformed data are skipped, errors
+// for all such rows are returned as [errors.Bundle].
+func (r *Reader) DecodeAll(v any) error {
+	if r == nil || r.s == nil {
+		return ErrNilReader
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return ErrInvalidTarget
+	}
+
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Pointer
+
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	errs := errors.NewBundle()
+
+	for {
+		row, err := r.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		item := reflect.New(elemType)
+		err = r.decodeRow(row, item.Elem())
+
+		if err != nil {
+			if !errors.As(err, new(*errors.Bundle)) {
+				return err
+			}
+
+			errs.Add(err)
+			continue
+		}
+
+		if isPtr {
+			slice.Set(reflect.Append(slice, item))
+		} else {
+			slice.Set(reflect.Append(slice, item.Elem()))
+		}
+	}
+
+	if !errs.IsEmpty() {
+		return errs
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error message with line and column
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns actual error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decodeRow decodes row into given struct
+func (r *Reader) decodeRow(row Row, v reflect.Value) error {
+	if len(r.Header) == 0 {
+		return ErrEmptyHeader
+	}
+
+	d, err := r.getDecoder(v.Type())
+
+	if err != nil {
+		return err
+	}
+
+	errs := errors.NewBundle()
+
+	for _, f := range d.fields {
+		if f.column == -1 {
+			continue
+		}
+
+		if row.Get(f.column) == "" {
+			if f.required {
+				errs.Add(&DecodeError{r.currentLine, f.name, ErrEmptyCell})
+			}
+
+			continue
+		}
+
+		err = decodeValue(row, f.column, fieldByIndex(v, f.index), f.layout)
+
+		if err != nil {
+			errs.Add(&DecodeError{r.currentLine, f.name, err})
+		}
+	}
+
+	if !errs.IsEmpty() {
+		return errs
+	}
+
+	return nil
+}
+
+// getDecoder returns decoder for given struct type
+func (r *Reader) getDecoder(t reflect.Type) (*decoder, error) {
+	if r.decoder != nil && r.decoder.typ == t {
+		return r.decoder, nil
+	}
+
+	d := &decoder{typ: t}
+
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous || !isReachable(t, sf.Index) {
+			continue
+		}
+
+		tag := sf.Tag.Get("csv")
+
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		if !isSupportedType(sf.Type) {
+			return nil, fmt.Errorf("%w %s of field %q", ErrUnsupportedType, sf.Type, sf.Name)
+		}
+
+		f := fieldInfo{
+			index:    sf.Index,
+			column:   r.Header.index(name),
+			name:     name,
+			layout:   sf.Tag.Get("layout"),
+			required: opts == "required",
+		}
+
+		if f.column == -1 && f.required {
+			return nil, fmt.Errorf("%w: %q", ErrMissingColumn, name)
+		}
+
+		d.fields = append(d.fields, f)
+	}
+
+	r.decoder = d
+
+	return d, nil
+}
+
+// fieldByIndex returns nested field with given index. Nil pointers to embedded
+// structs are allocated.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// isReachable returns false if field with given index is promoted through
+// unexported pointer to embedded struct, which can't be allocated
+func isReachable(t reflect.Type, index []int) bool {
+	for _, x := range index[:len(index)-1] {
+		f := t.Field(x)
+		t = f.Type
+
+		if t.Kind() == reflect.Pointer {
+			if !f.IsExported() {
+				return false
+			}
+
+			t = t.Elem()
+		}
+	}
+
+	return true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// index returns index of column with given name. Names are compared
+// case-insensitively if there is no exact match.
+func (h Header) index(name string) int {
+	for i, n := range h {
+		if n == name {
+			return i
+		}
+	}
+
+	for i, n := range h {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decodeValue decodes cell with given index into value
+func decodeValue(row Row, index int, v reflect.Value, layout string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return decodeValue(row, index, v.Elem(), layout)
+	}
+
+	switch v.Type() {
+	case timeType:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		t, err := time.Parse(layout, row.Get(index))
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t))
+		return nil
+
+	case durationType:
+		d, err := time.ParseDuration(row.Get(index))
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(
+			[]byte(row.Get(index)),
+		)
+	}
+
+	var err error
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(row.Get(index))
+	case reflect.Bool:
+		v.SetBool(row.GetB(index))
+	case reflect.Int:
+		var i int
+		i, err = row.GetI(index)
+		v.SetInt(int64(i))
+	case reflect.Int8:
+		var i int8
+		i, err = row.GetI8(index)
+		v.SetInt(int64(i))
+	case reflect.Int16:
+		var i int16
+		i, err = row.GetI16(index)
+		v.SetInt(int64(i))
+	case reflect.Int32:
+		var i int32
+		i, err = row.GetI32(index)
+		v.SetInt(int64(i))
+	case reflect.Int64:
+		var i int64
+		i, err = row.GetI64(index)
+		v.SetInt(i)
+	case reflect.Uint:
+		var u uint
+		u, err = row.GetU(index)
+		v.SetUint(uint64(u))
+	case reflect.Uint8:
+		var u uint8
+		u, err = row.GetU8(index)
+		v.SetUint(uint64(u))
+	case reflect.Uint16:
+		var u uint16
+		u, err = row.GetU16(index)
+		v.SetUint(uint64(u))
+	case reflect.Uint32:
+		var u uint32
+		u, err = row.GetU32(index)
+		v.SetUint(uint64(u))
+	case reflect.Uint64:
+		var u uint64
+		u, err = row.GetU64(index)
+		v.SetUint(u)
+	case reflect.Float32:
+		var f float32
+		f, err = row.GetF32(index)
+		v.SetFloat(float64(f))
+	case reflect.Float64:
+		var f float64
+		f, err = row.GetF(index)
+		v.SetFloat(f)
+	}
+
+	return err
+}
+
+// isSupportedType returns true if values of given type can be decoded
+func isSupportedType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
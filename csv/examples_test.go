@@ -12,6 +12,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -126,6 +127,43 @@ func ExampleReader_WithHeader() {
 	}
 }
 
+func ExampleReader_WithRFC4180() {
+	data := "id,name,comment\n" +
+		"1,\"Doe, John\",\"He said \"\"Hi\"\"\"\n" +
+		"2,Jane,\"multi\nline\"\n"
+
+	r := NewReader(strings.NewReader(data), ',').
+		WithRFC4180(true).
+		WithHeader(true)
+
+	for _, row := range r.Seq {
+		fmt.Printf("%q\n", row)
+	}
+
+	if r.Error() != nil {
+		fmt.Printf("Error: %v\n", r.Error())
+	}
+
+	// Output:
+	// ["1" "Doe, John" "He said \"Hi\""]
+	// ["2" "Jane" "multi\nline"]
+}
+
+func ExampleReader_Error() {
+	data := "1,John\n2,Jo\"hn\n"
+	r := NewReader(strings.NewReader(data), ',').WithRFC4180(true)
+
+	for _, row := range r.Seq {
+		fmt.Printf("%q\n", row)
+	}
+
+	fmt.Printf("Error: %v\n", r.Error())
+
+	// Output:
+	// ["1" "John"]
+	// Error: line 2, column 5: bare " in non-quoted field
+}
+
 func ExampleReader_Line() {
 	fd, err := os.Open("file.csv")
 
@@ -572,3 +610,113 @@ func ExampleHeader_Map() {
 		fmt.Printf("%#v\n", m)
 	}
 }
+
+func ExampleReader_Decode() {
+	type User struct {
+		ID      int       `csv:"id,required"`
+		Name    string    `csv:"name,required"`
+		Balance float64   `csv:"balance"`
+		Created time.Time `csv:"created" layout:"2006-01-02"`
+	}
+
+	data := "id,name,balance,created\n" +
+		"1,John,10.5,2025-01-02\n" +
+		"2,Jane,,2025-02-03\n"
+
+	r := NewReader(strings.NewReader(data), ',').WithHeader(true)
+
+	for {
+		var user User
+
+		err := r.Decode(&user)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+
+		fmt.Printf(
+			"%d: %s (%g) %s\n",
+			user.ID, user.Name, user.Balance, user.Created.Format("Jan 2"),
+		)
+	}
+
+	// Output:
+	// 1: John (10.5) Jan 2
+	// 2: Jane (0) Feb 3
+}
+
+func ExampleReader_DecodeAll() {
+	type Item struct {
+		ID    int     `csv:"id,required"`
+		Name  string  `csv:"name,required"`
+		Price float64 `csv:"price"`
+	}
+
+	data := "id,name,price\n" +
+		"1,Apple,1.5\n" +
+		"2,,abc\n" +
+		"3,Orange,2\n"
+
+	var items []Item
+
+	r := NewReader(strings.NewReader(data), ',').WithHeader(true)
+	err := r.DecodeAll(&items)
+
+	if err != nil {
+		fmt.Printf("Errors:\n%v\n", err)
+	}
+
+	fmt.Printf("Items: %v\n", items)
+
+	// Output:
+	// Errors:
+	// line 3, column "name": required cell is empty
+	// line 3, column "price": strconv.ParseFloat: parsing "abc": invalid syntax
+	// Items: [{1 Apple 1.5} {3 Orange 2}]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func ExampleNewWriter() {
+	w := NewWriter(os.Stdout, ',')
+
+	w.WriteHeader(Header{"ID", "Name", "Comment"})
+	w.Write(Row{"1", "Doe, John", `Said "Hi"`})
+	w.Flush()
+
+	// Output:
+	// ID,Name,Comment
+	// 1,"Doe, John","Said ""Hi"""
+}
+
+func ExampleWriter_WriteValues() {
+	w := NewWriter(os.Stdout, ';').WithTimeLayout("2006-01-02")
+
+	w.WriteValues(1, "John", 12.5, true, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	w.Flush()
+
+	// Output:
+	// 1;John;12.5;true;2025-01-01
+}
+
+func ExampleWriter_WriteAll() {
+	w := NewWriter(os.Stdout, ',').WithCRLF(false)
+
+	err := w.WriteAll([]Row{
+		{"1", "John", "Doe"},
+		{"2", "Fiammetta", "Miriana"},
+	})
+
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// 1,John,Doe
+	// 2,Fiammetta,Miriana
+}
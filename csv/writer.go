@@ -0,0 +1,280 @@
+package csv
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Writer is CSV writer struct. It is NOT safe for concurrent use.
+// Each Writer should be used by a single goroutine.
+type Writer struct {
+	comma      rune
+	useCRLF    bool
+	timeLayout string
+
+	out io.Writer
+	w   *bufio.Writer
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrNilWriter is returned when writer struct is nil
+	ErrNilWriter = errors.New("writer is nil")
+
+	// ErrInvalidComma is returned if comma is not valid field delimiter
+	ErrInvalidComma = errors.New("invalid field delimiter")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewWriter creates a new CSV writer that writes to w, using comma as the
+// field delimiter
+func NewWriter(w io.Writer, comma rune) *Writer {
+	if w == nil {
+		return nil
+	}
+
+	return &Writer{
+		comma:      comma,
+		timeLayout: time.RFC3339,
+		out:        w,
+		w:          bufio.NewWriter(w),
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WithCRLF configures the writer to use \r\n as the line terminator
+func (w *Writer) WithCRLF(flag bool) *Writer {
+	if w == nil || w.w == nil {
+		return nil
+	}
+
+	w.useCRLF = flag
+
+	return w
+}
+
+// WithTimeLayout sets layout used for formatting time.Time values (RFC3339
+// by default)
+func (w *Writer) WithTimeLayout(layout string) *Writer {
+	if w == nil || w.w == nil {
+		return nil
+	}
+
+	w.timeLayout = layout
+
+	return w
+}
+
+// WithBufferSize sets the size of the write buffer. It must be called before
+// writing any data.
+func (w *Writer) WithBufferSize(n int) *Writer {
+	if w == nil || w.w == nil {
+		return nil
+	}
+
+	w.w.Flush()
+	w.w = bufio.NewWriterSize(w.out, n)
+
+	return w
+}
+
+// WriteHeader writes header
+func (w *Writer) WriteHeader(h Header) error {
+	return w.Write(Row(h))
+}
+
+// Write writes row. Fields containing delimiter, quotes or line breaks are quoted.
+func (w *Writer) Write(r Row) error {
+	if w == nil || w.w == nil {
+		return ErrNilWriter
+	}
+
+	if !isValidComma(w.comma) {
+		return ErrInvalidComma
+	}
+
+	for i, c := range r {
+		if i > 0 {
+			w.w.WriteRune(w.comma)
+		}
+
+		w.writeField(c)
+	}
+
+	return w.writeLineEnd()
+}
+
+// WriteValues writes row with typed values. Supported types are string, []byte,
+// bool, all integer and float types, time.Time, time.Duration and fmt.Stringer.
+// nil is written as an empty cell.
+func (w *Writer) WriteValues(values ...any) error {
+	if w == nil || w.w == nil {
+		return ErrNilWriter
+	}
+
+	r := make(Row, len(values))
+
+	for i, v := range values {
+		r[i] = w.formatValue(v)
+	}
+
+	return w.Write(r)
+}
+
+// WriteAll writes all given rows and flushes buffer
+func (w *Writer) WriteAll(rows []Row) error {
+	if w == nil || w.w == nil {
+		return ErrNilWriter
+	}
+
+	for _, r := range rows {
+		err := w.Write(r)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// Flush writes any buffered data to the underlying writer
+func (w *Writer) Flush() error {
+	if w == nil || w.w == nil {
+		return ErrNilWriter
+	}
+
+	return w.w.Flush()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeField writes field and quotes it if required
+func (w *Writer) writeField(field string) {
+	if !w.isQuotingRequired(field) {
+		w.w.WriteString(field)
+		return
+	}
+
+	w.w.WriteByte('"')
+
+	for field != "" {
+		i := strings.IndexByte(field, '"')
+
+		if i == -1 {
+			w.w.WriteString(field)
+			break
+		}
+
+		w.w.WriteString(field[:i+1])
+		w.w.WriteByte('"')
+		field = field[i+1:]
+	}
+
+	w.w.WriteByte('"')
+}
+
+// writeLineEnd writes line terminator
+func (w *Writer) writeLineEnd() error {
+	var err error
+
+	if w.useCRLF {
+		_, err = w.w.WriteString("\r\n")
+	} else {
+		err = w.w.WriteByte('\n')
+	}
+
+	return err
+}
+
+// isQuotingRequired returns true if field must be quoted
+func (w *Writer) isQuotingRequired(field string) bool {
+	if field == "" {
+		return false
+	}
+
+	if field[0] == ' ' || field[0] == '\t' {
+		return true
+	}
+
+	return strings.ContainsRune(field, w.comma) ||
+		strings.ContainsAny(field, "\"\r\n")
+}
+
+// formatValue converts value to string
+func (w *Writer) formatValue(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case bool:
+		return strconv.FormatBool(t)
+	case int:
+		return strconv.Itoa(t)
+	case int8:
+		return strconv.FormatInt(int64(t), 10)
+	case int16:
+		return strconv.FormatInt(int64(t), 10)
+	case int32:
+		return strconv.FormatInt(int64(t), 10)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case uint:
+		return strconv.FormatUint(uint64(t), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(t), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(t), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(t), 10)
+	case uint64:
+		return strconv.FormatUint(t, 10)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(w.timeLayout)
+	case time.Duration:
+		return t.String()
+	case fmt.Stringer:
+		return t.String()
+	case error:
+		return t.Error()
+	}
+
+	return fmt.Sprint(v)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isValidComma returns true if given rune can be used as field delimiter
+func isValidComma(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' &&
+		r != utf8.RuneError && utf8.ValidRune(r)
+}
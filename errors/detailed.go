@@ -0,0 +1,260 @@
+package errors
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MAX_STACK_DEPTH is maximum number of frames captured by [Error]
+const MAX_STACK_DEPTH = 32
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error is an error with captured stack trace, machine-readable code and
+// key-value attributes
+type Error struct {
+	msg   string
+	code  string
+	attrs []Attr
+	cause error
+	stack []uintptr
+}
+
+// Attr is error attribute
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// Frame is stack frame
+type Frame struct {
+	Func string
+	File string
+	Line int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewError creates a new error with given message and captures the stack trace
+func NewError(msg string) *Error {
+	return &Error{msg: msg, stack: captureStack()}
+}
+
+// Errorf formats an error using [fmt.Errorf] and captures the stack trace. Error
+// wrapped using %w verb is available via Unwrap.
+func Errorf(format string, a ...any) *Error {
+	err := fmt.Errorf(format, a...)
+
+	return &Error{msg: err.Error(), cause: errors.Unwrap(err), stack: captureStack()}
+}
+
+// Wrap wraps given error with message and captures the stack trace. It returns
+// nil if err is nil.
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{msg: msg + ": " + err.Error(), cause: err, stack: captureStack()}
+}
+
+// CodeOf returns the first non-empty code found in err's chain
+func CodeOf(err error) string {
+	for err != nil {
+		e, ok := err.(*Error)
+
+		if ok && e != nil && e.code != "" {
+			return e.code
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return ""
+}
+
+// AttrsOf returns attributes of all errors in err's chain, starting from the
+// outermost error
+func AttrsOf(err error) []Attr {
+	var result []Attr
+
+	for err != nil {
+		e, ok := err.(*Error)
+
+		if ok && e != nil {
+			result = append(result, e.attrs...)
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return result
+}
+
+// StackOf returns stack trace of the innermost error with stack in err's chain
+func StackOf(err error) []Frame {
+	var result []Frame
+
+	for err != nil {
+		e, ok := err.(*Error)
+
+		if ok && e != nil && len(e.stack) != 0 {
+			result = e.Stack()
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WithCode sets error code
+func (e *Error) WithCode(code string) *Error {
+	if e == nil {
+		return nil
+	}
+
+	e.code = code
+
+	return e
+}
+
+// WithAttr adds attribute to error
+func (e *Error) WithAttr(key string, value any) *Error {
+	if e == nil || key == "" {
+		return e
+	}
+
+	e.attrs = append(e.attrs, Attr{key, value})
+
+	return e
+}
+
+// Error returns error message
+func (e *Error) Error() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.msg
+}
+
+// Unwrap returns wrapped error
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.cause
+}
+
+// Code returns error code
+func (e *Error) Code() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.code
+}
+
+// Attrs returns error attributes
+func (e *Error) Attrs() []Attr {
+	if e == nil {
+		return nil
+	}
+
+	return e.attrs
+}
+
+// Stack returns captured stack trace
+func (e *Error) Stack() []Frame {
+	if e == nil || len(e.stack) == 0 {
+		return nil
+	}
+
+	var result []Frame
+
+	frames := runtime.CallersFrames(e.stack)
+
+	for {
+		f, more := frames.Next()
+
+		result = append(result, Frame{f.Function, f.File, f.Line})
+
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+// Format implements [fmt.Formatter]. Verb %+v prints error message along with
+// code, attributes and stack trace collected from the whole chain.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Error())
+
+		code, attrs := CodeOf(e), AttrsOf(e)
+
+		if code != "" {
+			io.WriteString(s, "\nCode: "+code)
+		}
+
+		if len(attrs) != 0 {
+			io.WriteString(s, "\nAttributes:")
+
+			for _, a := range attrs {
+				fmt.Fprintf(s, "\n  %s: %v", a.Key, a.Value)
+			}
+		}
+
+		stack := StackOf(e)
+
+		if len(stack) != 0 {
+			io.WriteString(s, "\nStack:")
+
+			for _, f := range stack {
+				io.WriteString(s, "\n  "+f.String())
+			}
+		}
+
+	case verb == 'q':
+		io.WriteString(s, strconv.Quote(e.Error()))
+
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns string representation of frame
+func (f Frame) String() string {
+	return f.Func + " (" + f.File + ":" + strconv.Itoa(f.Line) + ")"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// captureStack captures stack trace of the caller of error constructor
+func captureStack() []uintptr {
+	pcs := make([]uintptr, MAX_STACK_DEPTH)
+	n := runtime.Callers(3, pcs)
+
+	return pcs[:n]
+}
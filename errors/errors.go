@@ -9,8 +9,10 @@ package errors
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -26,6 +28,21 @@ type Bundle struct {
 	errors   Errors
 }
 
+// KeyError is an error associated with a key (e.g. field or property name)
+type KeyError struct {
+	Key string
+	Err error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// errorInfo contains error info for JSON encoding
+type errorInfo struct {
+	Key     string `json:"key,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // New returns an error that formats as the given text. Each call to New returns
@@ -164,61 +181,98 @@ func (e Errors) Join() error {
 	return errors.Join(e...)
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+// Keys returns unique keys of keyed errors in the order of their first appearance
+func (e Errors) Keys() []string {
+	var result []string
 
-// Add appends one or more errors to the bundle.
-// Accepts error, string, []error, []string, [Errors], [Bundle], and *Bundle values.
-func (b *Bundle) Add(errs ...any) *Bundle {
-	if len(errs) == 0 || b == nil {
-		return b
+	for _, err := range e {
+		key := KeyOf(err)
+
+		if key != "" && !slices.Contains(result, key) {
+			result = append(result, key)
+		}
 	}
 
-	for _, err := range errs {
-		switch v := err.(type) {
-		case *Bundle:
-			if v != nil && len(v.errors) > 0 {
-				b.errors = append(b.errors, v.errors...)
-			}
+	return result
+}
 
-		case Bundle:
-			if len(v.errors) > 0 {
-				b.errors = append(b.errors, v.errors...)
-			}
+// ByKey returns all errors with the given key
+func (e Errors) ByKey(key string) Errors {
+	var result Errors
 
-		case []error:
-			for _, e := range v {
-				if e != nil {
-					b.errors = append(b.errors, e)
-				}
-			}
+	for _, err := range e {
+		if KeyOf(err) == key {
+			result = append(result, err)
+		}
+	}
 
-		case Errors:
-			for _, e := range v {
-				if e != nil {
-					b.errors = append(b.errors, e)
-				}
-			}
+	return result
+}
 
-		case []string:
-			for _, s := range v {
-				b.errors = append(b.errors, errors.New(s))
-			}
+// Group returns errors grouped by key. Errors without key are grouped under
+// empty key.
+func (e Errors) Group() map[string]Errors {
+	if e.IsEmpty() {
+		return nil
+	}
 
-		case error:
-			if v != nil {
-				b.errors = append(b.errors, v)
-			}
+	result := make(map[string]Errors)
 
-		case string:
-			b.errors = append(b.errors, errors.New(v))
+	for _, err := range e {
+		key := KeyOf(err)
+		result[key] = append(result[key], err)
+	}
+
+	return result
+}
+
+// Dedup returns a copy of the slice without errors with the same key and message
+func (e Errors) Dedup() Errors {
+	if e.IsEmpty() {
+		return nil
+	}
+
+	type errorID struct{ key, msg string }
+
+	var result Errors
+
+	index := make(map[errorID]bool, len(e))
+
+	for _, err := range e {
+		id := errorID{KeyOf(err), err.Error()}
+
+		if !index[id] {
+			index[id] = true
+			result = append(result, err)
 		}
 	}
 
-	if b.capacity > 0 && len(b.errors) > b.capacity {
-		b.errors = b.errors[len(b.errors)-b.capacity:]
+	return result
+}
+
+// MarshalJSON encodes errors as JSON array of objects with key, code and message
+func (e Errors) MarshalJSON() ([]byte, error) {
+	info := make([]errorInfo, 0, len(e))
+
+	for _, err := range e {
+		info = append(info, errorInfo{KeyOf(err), CodeOf(err), err.Error()})
 	}
 
-	return b
+	return json.Marshal(info)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Add appends one or more errors to the bundle.
+// Accepts error, string, []error, []string, [Errors], [Bundle], and *Bundle values.
+func (b *Bundle) Add(errs ...any) *Bundle {
+	return b.add("", errs)
+}
+
+// AddKey appends one or more errors associated with the given key (e.g. field or
+// property name) to the bundle. Accepts the same values as [Bundle.Add].
+func (b *Bundle) AddKey(key string, errs ...any) *Bundle {
+	return b.add(key, errs)
 }
 
 // Addf formats an error using [fmt.Errorf] and appends it to the bundle
@@ -320,9 +374,155 @@ func (b *Bundle) Join() error {
 	return b.errors.Join()
 }
 
+// Keys returns unique keys of keyed errors in the order of their first appearance
+func (b *Bundle) Keys() []string {
+	if b == nil {
+		return nil
+	}
+
+	return b.errors.Keys()
+}
+
+// ByKey returns all errors with the given key
+func (b *Bundle) ByKey(key string) Errors {
+	if b == nil {
+		return nil
+	}
+
+	return b.errors.ByKey(key)
+}
+
+// Group returns errors grouped by key. Errors without key are grouped under
+// empty key.
+func (b *Bundle) Group() map[string]Errors {
+	if b == nil {
+		return nil
+	}
+
+	return b.errors.Group()
+}
+
+// Dedup removes errors with the same key and message from the bundle, keeping
+// the first occurrence
+func (b *Bundle) Dedup() *Bundle {
+	if b == nil {
+		return nil
+	}
+
+	b.errors = b.errors.Dedup()
+
+	return b
+}
+
+// MarshalJSON encodes errors as JSON array of objects with key, code and message
+func (b *Bundle) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("[]"), nil
+	}
+
+	return b.errors.MarshalJSON()
+}
+
 // Reset removes all errors from the bundle without changing its capacity
 func (b *Bundle) Reset() {
 	if b != nil {
 		b.errors = nil
 	}
 }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error message
+func (e *KeyError) Error() string {
+	if e == nil || e.Err == nil {
+		return ""
+	}
+
+	return e.Err.Error()
+}
+
+// Unwrap returns wrapped error
+func (e *KeyError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.Err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// KeyOf returns the key of the first [KeyError] in err's chain
+func KeyOf(err error) string {
+	var keyErr *KeyError
+
+	if errors.As(err, &keyErr) && keyErr != nil {
+		return keyErr.Key
+	}
+
+	return ""
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// add appends errors with given key to the bundle
+func (b *Bundle) add(key string, errs []any) *Bundle {
+	if len(errs) == 0 || b == nil {
+		return b
+	}
+
+	start := len(b.errors)
+
+	for _, err := range errs {
+		switch v := err.(type) {
+		case *Bundle:
+			if v != nil && len(v.errors) > 0 {
+				b.errors = append(b.errors, v.errors...)
+			}
+
+		case Bundle:
+			if len(v.errors) > 0 {
+				b.errors = append(b.errors, v.errors...)
+			}
+
+		case []error:
+			for _, e := range v {
+				if e != nil {
+					b.errors = append(b.errors, e)
+				}
+			}
+
+		case Errors:
+			for _, e := range v {
+				if e != nil {
+					b.errors = append(b.errors, e)
+				}
+			}
+
+		case []string:
+			for _, s := range v {
+				b.errors = append(b.errors, errors.New(s))
+			}
+
+		case error:
+			if v != nil {
+				b.errors = append(b.errors, v)
+			}
+
+		case string:
+			b.errors = append(b.errors, errors.New(v))
+		}
+	}
+
+	if key != "" {
+		for i := start; i < len(b.errors); i++ {
+			b.errors[i] = &KeyError{key, b.errors[i]}
+		}
+	}
+
+	if b.capacity > 0 && len(b.errors) > b.capacity {
+		b.errors = b.errors[len(b.errors)-b.capacity:]
+	}
+
+	return b
+}
@@ -8,7 +8,9 @@ package errors
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	. "github.com/essentialkaos/check"
@@ -161,6 +163,11 @@ func (s *ErrorsSuite) TestNil(c *C) {
 	c.Assert(errs.Join(), IsNil)
 	c.Assert(errs.ErrorWithPrefix(""), Equals, "")
 	c.Assert(errs.Get(10), IsNil)
+	c.Assert(errs.AddKey("test", "error"), IsNil)
+	c.Assert(errs.Keys(), IsNil)
+	c.Assert(errs.ByKey("test"), IsNil)
+	c.Assert(errs.Group(), IsNil)
+	c.Assert(errs.Dedup(), IsNil)
 }
 
 func (s *ErrorsSuite) TestNoInit(c *C) {
@@ -206,3 +213,149 @@ func (s *ErrorsSuite) TestToBundle(c *C) {
 	c.Assert(errs.Num(), Equals, 2)
 	c.Assert(errs.Last(), DeepEquals, errors.New("Error 2"))
 }
+
+func (s *ErrorsSuite) TestDetailed(c *C) {
+	errBase := errors.New("base error")
+
+	err1 := NewError("can't connect").WithCode("E_CONN").WithAttr("host", "db1")
+	err2 := Errorf("can't load user %d: %w", 101, err1).WithAttr("id", 101).WithAttr("", 1)
+	err3 := Wrap(errBase, "can't read file")
+
+	c.Assert(err1.Error(), Equals, "can't connect")
+	c.Assert(err1.Code(), Equals, "E_CONN")
+	c.Assert(err1.Attrs(), DeepEquals, []Attr{{"host", "db1"}})
+	c.Assert(err1.Unwrap(), IsNil)
+	c.Assert(err1.Stack(), Not(HasLen), 0)
+	c.Assert(err1.Stack()[0].Func, Equals, "github.com/essentialkaos/ek/v14/errors.(*ErrorsSuite).TestDetailed")
+	c.Assert(err1.Stack()[0].String(), Matches, `.*TestDetailed \(.*errors_test.go:[0-9]+\)`)
+
+	c.Assert(err2.Error(), Equals, "can't load user 101: can't connect")
+	c.Assert(err2.Code(), Equals, "")
+	c.Assert(err2.Unwrap(), Equals, err1)
+	c.Assert(Is(err2, err1), Equals, true)
+	c.Assert(CodeOf(err2), Equals, "E_CONN")
+	c.Assert(AttrsOf(err2), DeepEquals, []Attr{{"id", 101}, {"host", "db1"}})
+	c.Assert(StackOf(err2)[0].Line, Equals, err1.Stack()[0].Line)
+
+	var target *Error
+	c.Assert(As(fmt.Errorf("wrapped: %w", err2), &target), Equals, true)
+	c.Assert(target, Equals, err2)
+
+	c.Assert(err3.Error(), Equals, "can't read file: base error")
+	c.Assert(Is(err3, errBase), Equals, true)
+	c.Assert(CodeOf(err3), Equals, "")
+	c.Assert(AttrsOf(err3), HasLen, 0)
+	c.Assert(StackOf(err3), Not(HasLen), 0)
+	c.Assert(Wrap(nil, "test"), IsNil)
+
+	c.Assert(StackOf(errBase), HasLen, 0)
+	c.Assert(CodeOf(nil), Equals, "")
+
+	c.Assert(fmt.Sprintf("%s", err2), Equals, "can't load user 101: can't connect")
+	c.Assert(fmt.Sprintf("%v", err2), Equals, "can't load user 101: can't connect")
+	c.Assert(fmt.Sprintf("%q", err1), Equals, `"can't connect"`)
+	c.Assert(
+		fmt.Sprintf("%+v", err2), Matches,
+		"(?s)can't load user 101: can't connect\nCode: E_CONN\nAttributes:\n  id: 101\n  host: db1\nStack:\n  .*TestDetailed.*",
+	)
+	c.Assert(fmt.Sprintf("%+v", NewError("test")), Matches, "(?s)test\nStack:\n.*")
+
+	var nilErr *Error
+
+	c.Assert(nilErr.WithCode("test"), IsNil)
+	c.Assert(nilErr.WithAttr("test", 1), IsNil)
+	c.Assert(nilErr.Error(), Equals, "")
+	c.Assert(nilErr.Unwrap(), IsNil)
+	c.Assert(nilErr.Code(), Equals, "")
+	c.Assert(nilErr.Attrs(), IsNil)
+	c.Assert(nilErr.Stack(), IsNil)
+	c.Assert(CodeOf(nilErr), Equals, "")
+	c.Assert(AttrsOf(nilErr), HasLen, 0)
+	c.Assert(StackOf(nilErr), HasLen, 0)
+}
+
+func (s *ErrorsSuite) TestKeys(c *C) {
+	b := NewBundle()
+
+	b.AddKey("port", "port must be greater than 0")
+	b.Add("unknown error")
+	b.AddKey("host", errors.New("host is empty"), nil, []string{"host is invalid"})
+	b.AddKey("port", NewError("port is busy").WithCode("E_BUSY"))
+	b.AddKey("", "no key")
+
+	c.Assert(b.Num(), Equals, 6)
+	c.Assert(b.Keys(), DeepEquals, []string{"port", "host"})
+	c.Assert(b.ByKey("port"), HasLen, 2)
+	c.Assert(b.ByKey("host"), HasLen, 2)
+	c.Assert(b.ByKey(""), HasLen, 2)
+	c.Assert(b.ByKey("user"), HasLen, 0)
+	c.Assert(b.Get(0).Error(), Equals, "port must be greater than 0")
+	c.Assert(KeyOf(b.Get(0)), Equals, "port")
+	c.Assert(KeyOf(b.Get(1)), Equals, "")
+	c.Assert(KeyOf(fmt.Errorf("wrapped: %w", b.Get(2))), Equals, "host")
+	c.Assert(CodeOf(b.Get(4)), Equals, "E_BUSY")
+
+	groups := b.Group()
+
+	c.Assert(groups, HasLen, 3)
+	c.Assert(groups["port"][1].Error(), Equals, "port is busy")
+	c.Assert(groups["host"][0].Error(), Equals, "host is empty")
+	c.Assert(groups[""][0].Error(), Equals, "unknown error")
+
+	baseErr := errors.New("base")
+	keyErr := &KeyError{"test", baseErr}
+
+	c.Assert(Is(keyErr, baseErr), Equals, true)
+	c.Assert(keyErr.Unwrap(), Equals, baseErr)
+
+	var nilKeyErr *KeyError
+
+	c.Assert(nilKeyErr.Error(), Equals, "")
+	c.Assert(nilKeyErr.Unwrap(), IsNil)
+	c.Assert(KeyOf(nil), Equals, "")
+	c.Assert(Errors{}.Group(), IsNil)
+}
+
+func (s *ErrorsSuite) TestDedup(c *C) {
+	b := NewBundle()
+
+	b.Add("error 1", "error 2", "error 1")
+	b.AddKey("port", "error 1", "error 1")
+	b.AddKey("host", "error 1")
+
+	c.Assert(b.Num(), Equals, 6)
+	c.Assert(b.Dedup(), Equals, b)
+	c.Assert(b.Num(), Equals, 4)
+	c.Assert(b.Error(), Equals, "error 1\nerror 2\nerror 1\nerror 1")
+	c.Assert(b.Keys(), DeepEquals, []string{"port", "host"})
+	c.Assert(Errors{}.Dedup(), IsNil)
+}
+
+func (s *ErrorsSuite) TestJSON(c *C) {
+	b := NewBundle()
+
+	data, err := json.Marshal(b)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `[]`)
+
+	b.Add("unknown error")
+	b.AddKey("port", NewError("port is busy").WithCode("E_BUSY"))
+
+	data, err = json.Marshal(b)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `[{"message":"unknown error"},{"key":"port","code":"E_BUSY","message":"port is busy"}]`)
+
+	data, err = json.Marshal(b.Group())
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"":[{"message":"unknown error"}],"port":[{"key":"port","code":"E_BUSY","message":"port is busy"}]}`)
+
+	var nilBundle *Bundle
+
+	data, err = nilBundle.MarshalJSON()
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `[]`)
+}
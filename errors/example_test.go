@@ -8,6 +8,7 @@ package errors
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -376,6 +377,67 @@ func ExampleBundle_Join() {
 	// Error 3
 }
 
+func ExampleBundle_AddKey() {
+	var errs Bundle
+
+	errs.AddKey("port", "Port must be greater than 0")
+	errs.AddKey("host", "Host is empty")
+	errs.AddKey("port", "Port must be less than 65536")
+
+	fmt.Printf("Keys: %v\n", errs.Keys())
+	fmt.Printf("Port errors: %v\n", errs.ByKey("port").Num())
+
+	// Output:
+	// Keys: [port host]
+	// Port errors: 2
+}
+
+func ExampleBundle_Group() {
+	var errs Bundle
+
+	errs.AddKey("port", "Port must be greater than 0")
+	errs.AddKey("host", "Host is empty")
+	errs.Add("Unknown error")
+
+	groups := errs.Group()
+
+	fmt.Println(groups["port"])
+	fmt.Println(groups["host"])
+	fmt.Println(groups[""])
+
+	// Output:
+	// Port must be greater than 0
+	// Host is empty
+	// Unknown error
+}
+
+func ExampleBundle_Dedup() {
+	var errs Bundle
+
+	errs.Add("Error 1", "Error 2", "Error 1")
+	errs.Dedup()
+
+	fmt.Println(errs.Error())
+
+	// Output:
+	// Error 1
+	// Error 2
+}
+
+func ExampleBundle_MarshalJSON() {
+	var errs Bundle
+
+	errs.AddKey("port", "Port must be greater than 0")
+	errs.AddKey("host", NewError("Host is empty").WithCode("E_EMPTY"))
+
+	data, _ := json.Marshal(&errs)
+
+	fmt.Println(string(data))
+
+	// Output:
+	// [{"key":"port","message":"Port must be greater than 0"},{"key":"host","code":"E_EMPTY","message":"Host is empty"}]
+}
+
 func ExampleBundle_Reset() {
 	var errs Bundle
 
@@ -387,3 +449,56 @@ func ExampleBundle_Reset() {
 	// Output:
 	// Has errors: false
 }
+
+func ExampleNewError() {
+	err := NewError("can't connect to database").
+		WithCode("E_DB_CONN").
+		WithAttr("host", "db1.example.com").
+		WithAttr("port", 5432)
+
+	fmt.Println(err)
+	fmt.Println(err.Code())
+	fmt.Println(err.Attrs())
+
+	// Print error with code, attributes and stack trace
+	// fmt.Printf("%+v\n", err)
+
+	// Output:
+	// can't connect to database
+	// E_DB_CONN
+	// [{host db1.example.com} {port 5432}]
+}
+
+func ExampleErrorf() {
+	errNotFound := New("not found")
+
+	err := Errorf("can't find user %d: %w", 101, errNotFound).WithCode("E_NO_USER")
+
+	fmt.Println(err)
+	fmt.Println(Is(err, errNotFound))
+
+	// Output:
+	// can't find user 101: not found
+	// true
+}
+
+func ExampleWrap() {
+	dbErr := NewError("connection refused").WithCode("E_DB_CONN")
+
+	err := Wrap(dbErr, "can't load user")
+
+	fmt.Println(err)
+	fmt.Println(CodeOf(err))
+
+	// Output:
+	// can't load user: connection refused
+	// E_DB_CONN
+}
+
+func ExampleStackOf() {
+	err := Wrap(NewError("connection refused"), "can't load user")
+
+	for _, frame := range StackOf(err) {
+		fmt.Println(frame)
+	}
+}
@@ -9,27 +9,67 @@ package events
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
-	"errors"
+	"cmp"
+	"context"
 	"fmt"
+	"path"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
+
+	"github.com/essentialkaos/ek/v14/errors"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// DEFAULT_WORKERS is default maximum number of goroutines used for asynchronous
+// dispatching
+const DEFAULT_WORKERS = 8
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // Dispatcher is event dispatcher
 type Dispatcher struct {
 	handlers map[string]handlers
+	patterns map[string]handlers
+	pool     *pool
+	seq      uint64
 	mx       sync.RWMutex
 }
 
 // Handler is a function that handles an event
 type Handler func(payload any)
 
+// HandlerOptions contains handler options
+type HandlerOptions struct {
+	// Priority is handler priority. Handlers with higher priority are called
+	// first, handlers with the same priority are called in registration order.
+	Priority int
+
+	// Once is flag for removing handler after the first call
+	Once bool
+
+	// StopPropagation is flag for stopping passing event to handlers with lower
+	// priority after this handler
+	StopPropagation bool
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// handler is registered handler
+type handler struct {
+	fn      handlerFunc
+	id      uintptr
+	options HandlerOptions
+	seq     uint64
+}
+
+// handlerFunc is a function that handles an event
+type handlerFunc func(ctx context.Context, payload any) error
+
 // handlers is a slice with handlers
-type handlers []Handler
+type handlers []*handler
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
@@ -42,6 +82,16 @@ var (
 
 	// ErrNilHandler is returned when handler is nil
 	ErrNilHandler = errors.New("handler must not be nil")
+
+	// ErrInvalidPattern is returned when event name pattern is malformed
+	ErrInvalidPattern = errors.New("event name pattern is malformed")
+
+	// ErrStopPropagation can be returned by typed handler for stopping passing
+	// event to handlers with lower priority
+	ErrStopPropagation = errors.New("stop propagation")
+
+	// ErrInvalidPayload is returned by typed handler if payload has wrong type
+	ErrInvalidPayload = errors.New("payload has wrong type")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -50,148 +100,307 @@ var (
 func NewDispatcher() *Dispatcher {
 	return &Dispatcher{
 		handlers: make(map[string]handlers),
+		patterns: make(map[string]handlers),
+		pool:     &pool{max: DEFAULT_WORKERS},
 		mx:       sync.RWMutex{},
 	}
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// AddHandler registers handler for specified event
-func (d *Dispatcher) AddHandler(ev string, handler Handler) error {
-	err := d.validateArguments(ev, handler, true)
-
-	if err != nil {
-		return err
+// WithWorkers sets maximum number of goroutines used for asynchronous
+// dispatching
+func (d *Dispatcher) WithWorkers(num int) *Dispatcher {
+	if d == nil || d.handlers == nil {
+		return nil
 	}
 
-	d.mx.Lock()
-	defer d.mx.Unlock()
+	d.pool.setMax(num)
 
-	if d.getHandlerIndex(ev, handler) != -1 {
-		return fmt.Errorf("handler already registered for given event (%s)", ev)
-	}
+	return d
+}
 
-	d.handlers[ev] = append(d.handlers[ev], handler)
+// ////////////////////////////////////////////////////////////////////////////////// //
 
-	return nil
+// AddHandler registers handler for specified event. Event name can be a pattern
+// with wildcards (e.g. "download.*"), see [path.Match] for pattern syntax.
+func (d *Dispatcher) AddHandler(ev string, handler Handler) error {
+	return d.AddHandlerWithOptions(ev, handler, HandlerOptions{})
 }
 
-// RemoveHandler removes handler for specified event
-func (d *Dispatcher) RemoveHandler(ev string, handler Handler) error {
-	err := d.validateArguments(ev, handler, true)
+// AddOnceHandler registers handler for specified event which is removed after
+// the first call
+func (d *Dispatcher) AddOnceHandler(ev string, handler Handler) error {
+	return d.AddHandlerWithOptions(ev, handler, HandlerOptions{Once: true})
+}
+
+// AddHandlerWithOptions registers handler for specified event with given options
+func (d *Dispatcher) AddHandlerWithOptions(ev string, fn Handler, options HandlerOptions) error {
+	err := d.validateArguments(ev, fn, true)
 
 	if err != nil {
 		return err
 	}
 
-	d.mx.Lock()
-	defer d.mx.Unlock()
+	return d.addHandler(ev, fn, options, func(_ context.Context, payload any) error {
+		fn(payload)
+		return nil
+	})
+}
 
-	i := d.getHandlerIndex(ev, handler)
+// RemoveHandler removes handler for specified event
+func (d *Dispatcher) RemoveHandler(ev string, handler Handler) error {
+	err := d.validateArguments(ev, handler, true)
 
-	if i == -1 {
-		return fmt.Errorf("handler is not registered for given event (%s)", ev)
+	if err != nil {
+		return err
 	}
 
-	copy(d.handlers[ev][i:], d.handlers[ev][i+1:])
-	d.handlers[ev][len(d.handlers[ev])-1] = nil
-	d.handlers[ev] = d.handlers[ev][:len(d.handlers[ev])-1]
-
-	return nil
+	return d.deleteHandler(ev, handler)
 }
 
 // HasHandler returns true if there is a handler for given event
 func (d *Dispatcher) HasHandler(ev string, handler Handler) bool {
-	err := d.validateArguments(ev, handler, true)
-
-	if err != nil {
+	if d.validateArguments(ev, handler, true) != nil {
 		return false
 	}
 
-	d.mx.RLock()
-	defer d.mx.RUnlock()
-
-	return d.getHandlerIndex(ev, handler) != -1
+	return d.hasHandler(ev, handler)
 }
 
-// Dispatch dispatches event with given payload
+// Dispatch dispatches event with given payload. Handlers are called one by one
+// in order of priority using pool of goroutines.
 func (d *Dispatcher) Dispatch(ev string, payload any) error {
+	return d.DispatchContext(context.Background(), ev, payload)
+}
+
+// DispatchContext dispatches event with given payload and context. Handlers are
+// called one by one in order of priority using pool of goroutines.
+func (d *Dispatcher) DispatchContext(ctx context.Context, ev string, payload any) error {
 	err := d.validateArguments(ev, nil, false)
 
 	if err != nil {
 		return err
 	}
 
-	d.mx.RLock()
-	defer d.mx.RUnlock()
+	handlers := d.getHandlers(ev)
 
-	if d.handlers[ev] == nil {
+	if len(handlers) == 0 {
 		return fmt.Errorf("no handlers for event %q", ev)
 	}
 
-	for _, h := range d.handlers[ev] {
-		go h(payload)
-	}
+	d.pool.submit(func() {
+		d.callHandlers(ctx, ev, handlers, payload)
+	})
 
 	return nil
 }
 
-// DispatchAndWait dispatches event with given payload and waits
-// until all handlers will be executed
+// DispatchAndWait dispatches event with given payload and waits until all
+// handlers will be executed. Failures of handlers (including panics) are
+// returned as [errors.Errors].
 func (d *Dispatcher) DispatchAndWait(ev string, payload any) error {
+	return d.DispatchAndWaitContext(context.Background(), ev, payload)
+}
+
+// DispatchAndWaitContext dispatches event with given payload and context and
+// waits until all handlers will be executed. Handlers are not called after
+// context cancellation. Failures of handlers (including panics and context
+// cancellation) are returned as [errors.Errors].
+func (d *Dispatcher) DispatchAndWaitContext(ctx context.Context, ev string, payload any) error {
 	err := d.validateArguments(ev, nil, false)
 
 	if err != nil {
 		return err
 	}
 
-	d.mx.RLock()
+	handlers := d.getHandlers(ev)
 
-	if d.handlers[ev] == nil {
-		d.mx.RUnlock()
+	if len(handlers) == 0 {
 		return fmt.Errorf("no handlers for event %q", ev)
 	}
 
-	handlers := append([]Handler(nil), d.handlers[ev]...)
+	errs := d.callHandlers(ctx, ev, handlers, payload)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
 
-	d.mx.RUnlock()
+// ////////////////////////////////////////////////////////////////////////////////// //
 
-	var wg sync.WaitGroup
+// addHandler registers handler function. Original handler is used for handler
+// identification.
+func (d *Dispatcher) addHandler(ev string, orig any, options HandlerOptions, fn handlerFunc) error {
+	isPattern := isPattern(ev)
 
-	for _, h := range handlers {
-		wg.Add(1)
+	if isPattern {
+		_, err := path.Match(ev, "")
 
-		go func(h Handler) {
-			defer wg.Done()
-			defer func() { recover() }()
-			h(payload)
-		}(h)
+		if err != nil {
+			return fmt.Errorf("%w (%s)", ErrInvalidPattern, ev)
+		}
 	}
 
-	wg.Wait()
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	registry := d.getRegistry(isPattern)
+
+	if getHandlerIndex(registry[ev], orig) != -1 {
+		return fmt.Errorf("handler already registered for given event (%s)", ev)
+	}
+
+	d.seq++
+	registry[ev] = append(registry[ev], &handler{fn, getFuncID(orig), options, d.seq})
 
 	return nil
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+// deleteHandler unregisters given handler
+func (d *Dispatcher) deleteHandler(ev string, handler any) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
 
-// getHandlerIndex returns the index of handler in d.handlers[ev].
-// Caller must hold d.mx (at minimum for reading) before calling this method.
-func (d *Dispatcher) getHandlerIndex(ev string, handler Handler) int {
-	hp := reflect.ValueOf(handler).Pointer()
+	registry := d.getRegistry(isPattern(ev))
+	i := getHandlerIndex(registry[ev], handler)
 
-	for i, h := range d.handlers[ev] {
-		if reflect.ValueOf(h).Pointer() == hp {
-			return i
+	if i == -1 {
+		return fmt.Errorf("handler is not registered for given event (%s)", ev)
+	}
+
+	d.removeHandler(registry, ev, i)
+
+	return nil
+}
+
+// hasHandler returns true if given handler is registered for given event
+func (d *Dispatcher) hasHandler(ev string, handler any) bool {
+	d.mx.RLock()
+	defer d.mx.RUnlock()
+
+	if isPattern(ev) {
+		return getHandlerIndex(d.patterns[ev], handler) != -1
+	}
+
+	return getHandlerIndex(d.handlers[ev], handler) != -1
+}
+
+// getRegistry returns map with handlers for patterns or exact event names.
+// Caller must hold d.mx.
+func (d *Dispatcher) getRegistry(isPattern bool) map[string]handlers {
+	if !isPattern {
+		return d.handlers
+	}
+
+	if d.patterns == nil {
+		d.patterns = make(map[string]handlers)
+	}
+
+	return d.patterns
+}
+
+// getHandlers returns sorted slice with handlers for given event
+func (d *Dispatcher) getHandlers(ev string) handlers {
+	d.mx.RLock()
+	defer d.mx.RUnlock()
+
+	matched := slices.Clone(d.handlers[ev])
+
+	for pattern, hs := range d.patterns {
+		ok, _ := path.Match(pattern, ev)
+
+		if ok {
+			matched = append(matched, hs...)
 		}
 	}
 
-	return -1
+	slices.SortFunc(matched, func(a, b *handler) int {
+		return cmp.Or(
+			cmp.Compare(b.options.Priority, a.options.Priority),
+			cmp.Compare(a.seq, b.seq),
+		)
+	})
+
+	for i, h := range matched {
+		if h.options.StopPropagation {
+			return matched[:i+1]
+		}
+	}
+
+	return matched
+}
+
+// callHandlers calls handlers one by one and returns their errors. Once handler
+// is removed from dispatcher right before the call, so it is called only once even
+// if event is dispatched concurrently.
+func (d *Dispatcher) callHandlers(ctx context.Context, ev string, hs handlers, payload any) errors.Errors {
+	var errs errors.Errors
+
+	for _, h := range hs {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		if h.options.Once && !d.removeOnceHandler(ev, h) {
+			continue
+		}
+
+		err := callHandler(ctx, ev, h.fn, payload)
+
+		if errors.Is(err, ErrStopPropagation) {
+			break
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// removeOnceHandler removes once handler matched for given event. It returns
+// false if handler was already removed.
+func (d *Dispatcher) removeOnceHandler(ev string, h *handler) bool {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	i := slices.Index(d.handlers[ev], h)
+
+	if i != -1 {
+		d.removeHandler(d.handlers, ev, i)
+		return true
+	}
+
+	for pattern, hs := range d.patterns {
+		i = slices.Index(hs, h)
+
+		if i != -1 {
+			d.removeHandler(d.patterns, pattern, i)
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeHandler removes handler with given index from registry.
+// Caller must hold d.mx.
+func (d *Dispatcher) removeHandler(registry map[string]handlers, ev string, index int) {
+	registry[ev] = slices.Delete(registry[ev], index, index+1)
+
+	if len(registry[ev]) == 0 {
+		delete(registry, ev)
+	}
 }
 
 // validateArguments checks that the event name are non-empty, and optionally that
 // the handler is non-nil
-func (d *Dispatcher) validateArguments(ev string, handler Handler, isHandlerRequired bool) error {
+func (d *Dispatcher) validateArguments(ev string, handler any, isHandlerRequired bool) error {
 	if d == nil || d.handlers == nil {
 		return ErrNilDispatcher
 	}
@@ -200,7 +409,7 @@ func (d *Dispatcher) validateArguments(ev string, handler Handler, isHandlerRequ
 		return ErrEmptyName
 	}
 
-	if isHandlerRequired && handler == nil {
+	if isHandlerRequired && (handler == nil || reflect.ValueOf(handler).IsNil()) {
 		return ErrNilHandler
 	}
 
@@ -208,3 +417,39 @@ func (d *Dispatcher) validateArguments(ev string, handler Handler, isHandlerRequ
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
+
+// getHandlerIndex returns the index of handler in given slice
+func getHandlerIndex(hs handlers, fn any) int {
+	id := getFuncID(fn)
+
+	for i, h := range hs {
+		if h.id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// getFuncID returns unique ID of given function
+func getFuncID(fn any) uintptr {
+	return reflect.ValueOf(fn).Pointer()
+}
+
+// isPattern returns true if given event name contains wildcards
+func isPattern(ev string) bool {
+	return strings.ContainsAny(ev, `*?[\`)
+}
+
+// callHandler calls handler and recovers panic
+func callHandler(ctx context.Context, ev string, h handlerFunc, payload any) (err error) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			err = fmt.Errorf("handler for event %q panicked: %v", ev, r)
+		}
+	}()
+
+	return h(ctx, payload)
+}
@@ -8,11 +8,16 @@ package events
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/essentialkaos/check"
+
+	"github.com/essentialkaos/ek/v14/errors"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -78,6 +83,306 @@ func (s *EventsSuite) TestDispatchAndWait(c *C) {
 	c.Assert(atomic.LoadUint32(&counter), Equals, uint32(11))
 }
 
+func (s *EventsSuite) TestPatterns(c *C) {
+	d := NewDispatcher()
+
+	var calls []string
+
+	h1 := func(payload any) { calls = append(calls, "exact:"+payload.(string)) }
+	h2 := func(payload any) { calls = append(calls, "download:"+payload.(string)) }
+	h3 := func(payload any) { calls = append(calls, "all:"+payload.(string)) }
+
+	c.Assert(d.AddHandler("download.start", h1), IsNil)
+	c.Assert(d.AddHandler("download.*", h2), IsNil)
+	c.Assert(d.AddHandler("*", h3), IsNil)
+	c.Assert(d.AddHandler("*", h3), ErrorMatches, `handler already registered for given event \(\*\)`)
+	c.Assert(d.AddHandler("download.[", h3), ErrorMatches, `event name pattern is malformed \(download.\[\)`)
+
+	c.Assert(d.HasHandler("download.*", h2), Equals, true)
+	c.Assert(d.HasHandler("download.*", h1), Equals, false)
+
+	c.Assert(d.DispatchAndWait("download.start", "1"), IsNil)
+	c.Assert(d.DispatchAndWait("download.error", "2"), IsNil)
+	c.Assert(d.DispatchAndWait("upload.start", "3"), IsNil)
+
+	c.Assert(calls, DeepEquals, []string{
+		"exact:1", "download:1", "all:1",
+		"download:2", "all:2",
+		"all:3",
+	})
+
+	c.Assert(d.RemoveHandler("download.*", h2), IsNil)
+	c.Assert(d.RemoveHandler("download.*", h2), NotNil)
+	c.Assert(d.RemoveHandler("*", h3), IsNil)
+
+	c.Assert(d.Dispatch("upload.start", "4"), ErrorMatches, `no handlers for event "upload.start"`)
+}
+
+func (s *EventsSuite) TestPriority(c *C) {
+	d := NewDispatcher()
+
+	var calls []int
+
+	c.Assert(d.AddHandlerWithOptions("test", func(payload any) { calls = append(calls, 1) }, HandlerOptions{Priority: -10}), IsNil)
+	c.Assert(d.AddHandlerWithOptions("test", func(payload any) { calls = append(calls, 2) }, HandlerOptions{Priority: 10}), IsNil)
+	c.Assert(d.AddHandlerWithOptions("t*", func(payload any) { calls = append(calls, 3) }, HandlerOptions{Priority: 20}), IsNil)
+	c.Assert(d.AddHandler("test", func(payload any) { calls = append(calls, 4) }), IsNil)
+	c.Assert(d.AddHandler("te*", func(payload any) { calls = append(calls, 5) }), IsNil)
+
+	c.Assert(d.DispatchAndWait("test", nil), IsNil)
+	c.Assert(calls, DeepEquals, []int{3, 2, 4, 5, 1})
+}
+
+func (s *EventsSuite) TestOnce(c *C) {
+	d := NewDispatcher()
+
+	var calls []int
+
+	h1 := func(payload any) { calls = append(calls, 1) }
+	h2 := func(payload any) { calls = append(calls, 2) }
+
+	c.Assert(d.AddOnceHandler("test", h1), IsNil)
+	c.Assert(d.AddOnceHandler("t*", h2), IsNil)
+	c.Assert(d.HasHandler("test", h1), Equals, true)
+
+	c.Assert(d.DispatchAndWait("test", nil), IsNil)
+	c.Assert(d.DispatchAndWait("test", nil), NotNil)
+
+	c.Assert(calls, DeepEquals, []int{1, 2})
+	c.Assert(d.HasHandler("test", h1), Equals, false)
+	c.Assert(d.HasHandler("t*", h2), Equals, false)
+}
+
+func (s *EventsSuite) TestStopPropagation(c *C) {
+	d := NewDispatcher()
+
+	var calls []int
+
+	h1 := func(payload any) { calls = append(calls, 1) }
+	h2 := func(payload any) { calls = append(calls, 2) }
+	h3 := func(payload any) { calls = append(calls, 3) }
+
+	c.Assert(d.AddHandlerWithOptions("test", h1, HandlerOptions{Priority: 10}), IsNil)
+	c.Assert(d.AddHandlerWithOptions("test", h2, HandlerOptions{StopPropagation: true, Once: true}), IsNil)
+	c.Assert(d.AddOnceHandler("*", h3), IsNil)
+
+	c.Assert(d.DispatchAndWait("test", nil), IsNil)
+	c.Assert(calls, DeepEquals, []int{1, 2})
+	c.Assert(d.HasHandler("*", h3), Equals, true)
+
+	c.Assert(d.DispatchAndWait("test", nil), IsNil)
+	c.Assert(calls, DeepEquals, []int{1, 2, 1, 3})
+}
+
+func (s *EventsSuite) TestPanic(c *C) {
+	d := NewDispatcher()
+
+	var called bool
+
+	c.Assert(d.AddHandlerWithOptions("test", func(payload any) { panic("ERROR") }, HandlerOptions{Priority: 1}), IsNil)
+	c.Assert(d.AddHandler("test", func(payload any) { called = true }), IsNil)
+
+	err := d.DispatchAndWait("test", nil)
+
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, `handler for event "test" panicked: ERROR`)
+	c.Assert(called, Equals, true)
+}
+
+func (s *EventsSuite) TestTypedEvents(c *C) {
+	type Progress struct {
+		File string
+		Done int
+	}
+
+	d := NewDispatcher()
+	ev := NewEvent[Progress]("download.progress")
+
+	c.Assert(ev.Name(), Equals, "download.progress")
+
+	var calls []string
+
+	h1 := func(ctx context.Context, p Progress) error {
+		calls = append(calls, fmt.Sprintf("h1:%s:%d", p.File, p.Done))
+		return nil
+	}
+
+	h2 := func(ctx context.Context, p Progress) error {
+		return fmt.Errorf("can't handle %s", p.File)
+	}
+
+	h3 := func(ctx context.Context, p Progress) error {
+		panic("ERROR")
+	}
+
+	c.Assert(ev.AddHandlerWithOptions(d, h1, HandlerOptions{Priority: 10}), IsNil)
+	c.Assert(ev.AddHandler(d, h2), IsNil)
+	c.Assert(ev.AddHandler(d, h3), IsNil)
+	c.Assert(ev.AddHandler(d, h3), NotNil)
+	c.Assert(d.AddHandler("download.*", basicTestHandler), IsNil)
+	c.Assert(ev.HasHandler(d, h1), Equals, true)
+
+	err := ev.DispatchAndWait(context.Background(), d, Progress{"file.zip", 50})
+
+	c.Assert(err, NotNil)
+	c.Assert(calls, DeepEquals, []string{"h1:file.zip:50"})
+
+	errs, ok := err.(errors.Errors)
+
+	c.Assert(ok, Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0], ErrorMatches, "can't handle file.zip")
+	c.Assert(errs[1], ErrorMatches, `handler for event "download.progress" panicked: ERROR`)
+
+	c.Assert(ev.RemoveHandler(d, h2), IsNil)
+	c.Assert(ev.RemoveHandler(d, h2), NotNil)
+	c.Assert(ev.RemoveHandler(d, h3), IsNil)
+	c.Assert(ev.HasHandler(d, h2), Equals, false)
+
+	c.Assert(ev.DispatchAndWait(context.Background(), d, Progress{"file.zip", 100}), IsNil)
+
+	err = d.DispatchAndWait("download.progress", "100")
+	c.Assert(err, ErrorMatches, `payload has wrong type \(string\)`)
+
+	c.Assert(ev.Dispatch(context.Background(), d, Progress{"file.zip", 100}), IsNil)
+}
+
+func (s *EventsSuite) TestTypedStopPropagation(c *C) {
+	d := NewDispatcher()
+	ev := NewEvent[int]("test")
+
+	var sum atomic.Int32
+
+	h1 := func(ctx context.Context, v int) error {
+		sum.Add(int32(v))
+		return ErrStopPropagation
+	}
+
+	h2 := func(ctx context.Context, v int) error {
+		sum.Add(int32(v))
+		return nil
+	}
+
+	c.Assert(ev.AddHandlerWithOptions(d, h1, HandlerOptions{Priority: 1}), IsNil)
+	c.Assert(ev.AddHandler(d, h2), IsNil)
+
+	c.Assert(ev.DispatchAndWait(context.Background(), d, 5), IsNil)
+	c.Assert(sum.Load(), Equals, int32(5))
+}
+
+func (s *EventsSuite) TestStopPropagationOnce(c *C) {
+	d := NewDispatcher()
+	ev := NewEvent[bool]("test")
+
+	var calls atomic.Int32
+
+	h1 := func(ctx context.Context, stop bool) error {
+		if stop {
+			return fmt.Errorf("stopped: %w", ErrStopPropagation)
+		}
+
+		return nil
+	}
+
+	h2 := func(ctx context.Context, stop bool) error {
+		calls.Add(1)
+		return nil
+	}
+
+	c.Assert(ev.AddHandlerWithOptions(d, h1, HandlerOptions{Priority: 1}), IsNil)
+	c.Assert(ev.AddHandlerWithOptions(d, h2, HandlerOptions{Once: true}), IsNil)
+
+	// Once handler must stay registered if propagation was stopped before it
+	c.Assert(ev.DispatchAndWait(context.Background(), d, true), IsNil)
+	c.Assert(calls.Load(), Equals, int32(0))
+	c.Assert(ev.HasHandler(d, h2), Equals, true)
+
+	c.Assert(ev.DispatchAndWait(context.Background(), d, false), IsNil)
+	c.Assert(calls.Load(), Equals, int32(1))
+	c.Assert(ev.HasHandler(d, h2), Equals, false)
+
+	c.Assert(ev.DispatchAndWait(context.Background(), d, false), IsNil)
+	c.Assert(calls.Load(), Equals, int32(1))
+
+	// Once handler must be called only once by concurrent dispatches
+	c.Assert(ev.AddHandlerWithOptions(d, h2, HandlerOptions{Once: true}), IsNil)
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Go(func() {
+			ev.DispatchAndWait(context.Background(), d, false)
+		})
+	}
+
+	wg.Wait()
+
+	c.Assert(calls.Load(), Equals, int32(2))
+}
+
+func (s *EventsSuite) TestContext(c *C) {
+	d := NewDispatcher()
+	ev := NewEvent[string]("test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+
+	h1 := func(ctx context.Context, v string) error {
+		calls++
+		cancel()
+		return nil
+	}
+
+	h2 := func(ctx context.Context, v string) error {
+		calls++
+		return nil
+	}
+
+	c.Assert(ev.AddHandlerWithOptions(d, h1, HandlerOptions{Priority: 1}), IsNil)
+	c.Assert(ev.AddHandler(d, h2), IsNil)
+
+	err := ev.DispatchAndWait(ctx, d, "test")
+
+	c.Assert(err, ErrorMatches, "context canceled")
+	c.Assert(calls, Equals, 1)
+}
+
+func (s *EventsSuite) TestPool(c *C) {
+	d := NewDispatcher().WithWorkers(2)
+
+	var active, maxActive atomic.Int32
+	var wg sync.WaitGroup
+
+	c.Assert(d.AddHandler("test", func(payload any) {
+		n := active.Add(1)
+
+		for {
+			m := maxActive.Load()
+
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		active.Add(-1)
+		wg.Done()
+	}), IsNil)
+
+	for range 10 {
+		wg.Add(1)
+		c.Assert(d.Dispatch("test", nil), IsNil)
+	}
+
+	wg.Wait()
+
+	c.Assert(maxActive.Load(), Equals, int32(2))
+
+	var nd *Dispatcher
+	c.Assert(nd.WithWorkers(2), IsNil)
+}
+
 func (s *EventsSuite) TestNil(c *C) {
 	var d *Dispatcher
 
@@ -86,6 +391,18 @@ func (s *EventsSuite) TestNil(c *C) {
 	c.Assert(d.Dispatch("test", nil), NotNil)
 	c.Assert(d.HasHandler("test", nil), Equals, false)
 	c.Assert(d.DispatchAndWait("test", nil), NotNil)
+	c.Assert(d.AddOnceHandler("test", basicTestHandler), NotNil)
+	c.Assert(d.AddHandlerWithOptions("test", basicTestHandler, HandlerOptions{}), NotNil)
+
+	ev := NewEvent[string]("test")
+	h := func(ctx context.Context, v string) error { return nil }
+
+	c.Assert(ev.AddHandler(d, h), Equals, ErrNilDispatcher)
+	c.Assert(ev.RemoveHandler(d, h), Equals, ErrNilDispatcher)
+	c.Assert(ev.HasHandler(d, h), Equals, false)
+	c.Assert(ev.Dispatch(context.Background(), d, ""), Equals, ErrNilDispatcher)
+	c.Assert(ev.DispatchAndWait(context.Background(), d, ""), Equals, ErrNilDispatcher)
+	c.Assert(ev.AddHandler(NewDispatcher(), nil), Equals, ErrNilHandler)
 
 	c.Assert(d.validateArguments("test", basicTestHandler, true), NotNil)
 }
@@ -8,7 +8,11 @@ package events
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
 	"fmt"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/errors"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -32,6 +36,56 @@ func ExampleDispatcher_AddHandler() {
 	fmt.Println(err) // nil
 }
 
+func ExampleDispatcher_AddHandler_pattern() {
+	d := NewDispatcher()
+
+	// Handler will be called for all events with "download." prefix
+	d.AddHandler("download.*", func(payload any) {
+		fmt.Printf("Download event: %v\n", payload)
+	})
+
+	d.DispatchAndWait("download.start", "file.zip")
+	d.DispatchAndWait("download.complete", "file.zip")
+
+	// Output:
+	// Download event: file.zip
+	// Download event: file.zip
+}
+
+func ExampleDispatcher_AddOnceHandler() {
+	d := NewDispatcher()
+
+	d.AddOnceHandler("myEvent", testHandler)
+
+	fmt.Println(d.DispatchAndWait("myEvent", "Hello!"))
+	fmt.Println(d.DispatchAndWait("myEvent", "Hello!"))
+
+	// Output:
+	// Got payload: Hello!
+	// <nil>
+	// no handlers for event "myEvent"
+}
+
+func ExampleDispatcher_AddHandlerWithOptions() {
+	d := NewDispatcher()
+
+	d.AddHandler("*.error", func(payload any) {
+		fmt.Printf("Generic error handler: %v\n", payload)
+	})
+
+	// This handler will be called first, and other handlers will be ignored
+	d.AddHandlerWithOptions("download.error", func(payload any) {
+		fmt.Printf("Download error handler: %v\n", payload)
+	}, HandlerOptions{Priority: 10, StopPropagation: true})
+
+	d.DispatchAndWait("download.error", "timeout")
+	d.DispatchAndWait("upload.error", "timeout")
+
+	// Output:
+	// Download error handler: timeout
+	// Generic error handler: timeout
+}
+
 func ExampleDispatcher_RemoveHandler() {
 	d := NewDispatcher()
 
@@ -76,6 +130,65 @@ func ExampleDispatcher_DispatchAndWait() {
 	fmt.Println(err) // nil
 }
 
+func ExampleDispatcher_WithWorkers() {
+	// Use no more than 2 goroutines for asynchronous dispatching
+	d := NewDispatcher().WithWorkers(2)
+
+	d.AddHandler("myEvent", testHandler)
+
+	err := d.Dispatch("myEvent", "Hello!")
+
+	fmt.Println(err) // nil
+}
+
+func ExampleDispatcher_DispatchAndWaitContext() {
+	d := NewDispatcher()
+
+	d.AddHandler("myEvent", testHandler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := d.DispatchAndWaitContext(ctx, "myEvent", "Hello!")
+
+	fmt.Println(err)
+
+	// Output:
+	// Got payload: Hello!
+	// <nil>
+}
+
+func ExampleNewEvent() {
+	type Progress struct {
+		File string
+		Done int
+	}
+
+	d := NewDispatcher()
+	ev := NewEvent[Progress]("download.progress")
+
+	ev.AddHandler(d, func(ctx context.Context, p Progress) error {
+		fmt.Printf("%s: %d%%\n", p.File, p.Done)
+		return nil
+	})
+
+	ev.AddHandler(d, func(ctx context.Context, p Progress) error {
+		return fmt.Errorf("can't save progress for %s", p.File)
+	})
+
+	err := ev.DispatchAndWait(context.Background(), d, Progress{"file.zip", 50})
+
+	if err != nil {
+		for _, e := range err.(errors.Errors) {
+			fmt.Println("Error:", e)
+		}
+	}
+
+	// Output:
+	// file.zip: 50%
+	// Error: can't save progress for file.zip
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 func testHandler(payload any) {
@@ -0,0 +1,66 @@
+package events
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pool is bounded pool of goroutines. Goroutines are started on demand and
+// stopped when there are no queued tasks.
+type pool struct {
+	queue  []func()
+	active int
+	max    int
+	mx     sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// submit adds task to the queue and starts new goroutine if required
+func (p *pool) submit(task func()) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.queue = append(p.queue, task)
+
+	if p.active < p.max {
+		p.active++
+		go p.work()
+	}
+}
+
+// setMax sets maximum number of goroutines
+func (p *pool) setMax(num int) {
+	p.mx.Lock()
+	p.max = max(num, 1)
+	p.mx.Unlock()
+}
+
+// work executes queued tasks until queue is empty
+func (p *pool) work() {
+	for {
+		p.mx.Lock()
+
+		if len(p.queue) == 0 {
+			p.active--
+			p.mx.Unlock()
+			return
+		}
+
+		task := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+
+		p.mx.Unlock()
+
+		task()
+	}
+}
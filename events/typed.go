@@ -0,0 +1,99 @@
+package events
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Event is typed event. Handlers of typed event are registered in [Dispatcher]
+// along with untyped handlers, so all dispatcher features (patterns, priorities,
+// once-only handlers) are available for typed events too.
+type Event[T any] struct {
+	name string
+}
+
+// TypedHandler is a function that handles typed event. Handler can return
+// [ErrStopPropagation] for stopping passing event to handlers with lower
+// priority.
+type TypedHandler[T any] func(ctx context.Context, payload T) error
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewEvent creates new typed event with given name
+func NewEvent[T any](name string) Event[T] {
+	return Event[T]{name}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Name returns event name
+func (e Event[T]) Name() string {
+	return e.name
+}
+
+// AddHandler registers handler for event in given dispatcher
+func (e Event[T]) AddHandler(d *Dispatcher, handler TypedHandler[T]) error {
+	return e.AddHandlerWithOptions(d, handler, HandlerOptions{})
+}
+
+// AddHandlerWithOptions registers handler with given options for event in given
+// dispatcher
+func (e Event[T]) AddHandlerWithOptions(d *Dispatcher, handler TypedHandler[T], options HandlerOptions) error {
+	err := d.validateArguments(e.name, handler, true)
+
+	if err != nil {
+		return err
+	}
+
+	return d.addHandler(e.name, handler, options, func(ctx context.Context, payload any) error {
+		p, ok := payload.(T)
+
+		if !ok && payload != nil {
+			return fmt.Errorf("%w (%T)", ErrInvalidPayload, payload)
+		}
+
+		return handler(ctx, p)
+	})
+}
+
+// RemoveHandler removes handler for event from given dispatcher
+func (e Event[T]) RemoveHandler(d *Dispatcher, handler TypedHandler[T]) error {
+	err := d.validateArguments(e.name, handler, true)
+
+	if err != nil {
+		return err
+	}
+
+	return d.deleteHandler(e.name, handler)
+}
+
+// HasHandler returns true if given handler is registered for event in given
+// dispatcher
+func (e Event[T]) HasHandler(d *Dispatcher, handler TypedHandler[T]) bool {
+	if d.validateArguments(e.name, handler, true) != nil {
+		return false
+	}
+
+	return d.hasHandler(e.name, handler)
+}
+
+// Dispatch dispatches event with given payload using given dispatcher
+func (e Event[T]) Dispatch(ctx context.Context, d *Dispatcher, payload T) error {
+	return d.DispatchContext(ctx, e.name, payload)
+}
+
+// DispatchAndWait dispatches event with given payload using given dispatcher and
+// waits until all handlers will be executed. Handler failures are returned as
+// [errors.Errors].
+func (e Event[T]) DispatchAndWait(ctx context.Context, d *Dispatcher, payload T) error {
+	return d.DispatchAndWaitContext(ctx, e.name, payload)
+}
@@ -0,0 +1,382 @@
+package knf
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/errors"
+	"github.com/essentialkaos/ek/v14/knf/value"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	TAG_NAME      = "knf"      // Tag with property or section name
+	TAG_DEFAULT   = "default"  // Tag with default value
+	TAG_VALIDATOR = "validate" // Tag with validators
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TagValidator is validator which can be used in struct tags
+type TagValidator struct {
+	Func  PropertyValidator             // Validation function
+	Parse func(arg string) (any, error) // Argument parser (argument passed as is if nil)
+}
+
+// TagValidators is a map with validators which can be used in struct tags
+type TagValidators map[string]TagValidator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decoder is struct decoder
+type decoder struct {
+	config     *Config
+	validators []TagValidators
+	checks     Validators
+	defaults   map[string]string
+	errs       *errors.Bundle
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	modeType     = reflect.TypeFor[os.FileMode]()
+	timeType     = reflect.TypeFor[time.Time]()
+	locationType = reflect.TypeFor[*time.Location]()
+	listType     = reflect.TypeFor[[]string]()
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrInvalidTarget is returned by Decode if target is not a pointer to struct
+var ErrInvalidTarget = errors.New("target must be a non-nil pointer to struct")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Decode fills given struct with global configuration values
+func Decode(v any, validators ...TagValidators) errors.Errors {
+	cfg := global.Load()
+
+	if cfg == nil {
+		return errors.Errors{ErrNilConfig}
+	}
+
+	return cfg.Decode(v, validators...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Decode fills given struct with configuration values and runs validators
+// declared in tags. Returns all decoding and validation errors.
+//
+// Property name is defined in "knf" tag ("section:prop"). Field with struct type
+// and section name in "knf" tag ("section") is decoded as section, fields of such
+// struct can use only property name in tag ("prop"). Option "size" after property
+// name ("section:prop,size") is used for parsing size values into unsigned
+// integers. Field value is not changed if property is not set and there is no
+// default value in "default" tag.
+//
+// Validators are listed in "validate" tag separated by comma, optional argument is
+// defined in parentheses (e.g. "Set,Greater(1024),network.Port"). Validators are
+// looked up in given maps with validators. Validators check values with applied
+// defaults.
+func (c *Config) Decode(v any, validators ...TagValidators) errors.Errors {
+	if c == nil {
+		return errors.Errors{ErrNilConfig}
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.Errors{ErrInvalidTarget}
+	}
+
+	d := &decoder{
+		config:     c,
+		validators: validators,
+		errs:       errors.NewBundle(),
+	}
+
+	d.decodeStruct(rv.Elem(), "")
+
+	vc := c
+
+	if len(d.defaults) != 0 {
+		vc = c.withDefaults(d.defaults)
+	}
+
+	for _, check := range d.checks {
+		d.errs.AddKey(check.Property, check.Func(vc, check.Property, check.Value))
+	}
+
+	return d.errs.All()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// decodeStruct decodes struct fields
+func (d *decoder) decodeStruct(rv reflect.Value, section string) {
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, opts, _ := strings.Cut(field.Tag.Get(TAG_NAME), ",")
+
+		if tag == "-" {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			switch {
+			case tag == "":
+				d.decodeStruct(rv.Field(i), section)
+			case strings.Contains(tag, _SYMBOL_DELIMITER):
+				d.errs.Addf("field %s: struct can't be decoded from property %q", field.Name, tag)
+			default:
+				d.decodeStruct(rv.Field(i), tag)
+			}
+
+			continue
+		}
+
+		if tag == "" {
+			continue
+		}
+
+		prop := tag
+
+		if !strings.Contains(tag, _SYMBOL_DELIMITER) && section != "" {
+			prop = section + _SYMBOL_DELIMITER + tag
+		}
+
+		if !isValidPropName(prop) {
+			d.errs.Addf("field %s: invalid property name %q", field.Name, prop)
+			continue
+		}
+
+		err := d.decodeField(rv.Field(i), prop, opts, field.Tag.Get(TAG_DEFAULT))
+
+		if err != nil {
+			d.errs.AddKey(prop, fmt.Errorf("field %s: %w", field.Name, err))
+		}
+
+		d.addChecks(field, prop)
+	}
+}
+
+// decodeField sets field value
+func (d *decoder) decodeField(rv reflect.Value, prop, opts, defValue string) error {
+	raw := d.config.getValue(prop)
+
+	if raw == "" && defValue != "" {
+		raw = defValue
+
+		if d.defaults == nil {
+			d.defaults = make(map[string]string)
+		}
+
+		d.defaults[strings.ToLower(prop)] = defValue
+	}
+
+	if raw == "" {
+		return nil
+	}
+
+	switch rv.Type() {
+	case durationType:
+		rv.SetInt(int64(value.ParseTimeDuration(raw)))
+		return nil
+	case modeType:
+		rv.SetUint(uint64(value.ParseMode(raw)))
+		return nil
+	case timeType:
+		rv.Set(reflect.ValueOf(value.ParseTimestamp(raw)))
+		return nil
+	case locationType:
+		rv.Set(reflect.ValueOf(value.ParseTimezone(raw)))
+		return nil
+	case listType:
+		rv.Set(reflect.ValueOf(value.ParseList(raw)))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(raw)
+
+	case reflect.Bool:
+		rv.SetBool(value.ParseBool(raw))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := value.ParseInt64(raw)
+
+		if rv.OverflowInt(v) {
+			return fmt.Errorf("value %d overflows %s", v, rv.Type())
+		}
+
+		rv.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+
+		if opts == "size" {
+			v = value.ParseSize(raw)
+		} else {
+			v = value.ParseUint64(raw)
+		}
+
+		if rv.OverflowUint(v) {
+			return fmt.Errorf("value %d overflows %s", v, rv.Type())
+		}
+
+		rv.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(value.ParseFloat(raw))
+
+	default:
+		return fmt.Errorf("unsupported type %s", rv.Type())
+	}
+
+	return nil
+}
+
+// addChecks adds validators from field tag
+func (d *decoder) addChecks(field reflect.StructField, prop string) {
+	for _, v := range splitValidators(field.Tag.Get(TAG_VALIDATOR)) {
+		name, arg, hasArg := strings.Cut(v, "(")
+
+		if hasArg {
+			if !strings.HasSuffix(arg, ")") {
+				d.errs.AddKey(prop, fmt.Errorf("field %s: invalid validator %q", field.Name, v))
+				continue
+			}
+
+			arg = strings.TrimSuffix(arg, ")")
+		}
+
+		validator, ok := d.findValidator(name)
+
+		if !ok {
+			d.errs.AddKey(prop, fmt.Errorf("field %s: unknown validator %q", field.Name, name))
+			continue
+		}
+
+		var val any = arg
+
+		if validator.Parse != nil {
+			var err error
+
+			val, err = validator.Parse(arg)
+
+			if err != nil {
+				d.errs.AddKey(prop, fmt.Errorf("field %s: invalid argument for validator %q: %w", field.Name, name, err))
+				continue
+			}
+		}
+
+		d.checks = append(d.checks, &Validator{prop, validator.Func, val})
+	}
+}
+
+// findValidator finds validator with given name
+func (d *decoder) findValidator(name string) (TagValidator, bool) {
+	for _, vs := range d.validators {
+		v, ok := vs[name]
+
+		if ok && v.Func != nil {
+			return v, true
+		}
+	}
+
+	return TagValidator{}, false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// splitValidators splits validators tag by commas outside parentheses
+func splitValidators(tag string) []string {
+	var result []string
+	var depth, start int
+
+	for i, r := range tag {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = appendValidator(result, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return appendValidator(result, tag[start:])
+}
+
+// appendValidator appends non-empty validator to slice
+func appendValidator(validators []string, v string) []string {
+	v = strings.TrimSpace(v)
+
+	if v == "" {
+		return validators
+	}
+
+	return append(validators, v)
+}
+
+// withDefaults returns copy of configuration with given default values of unset
+// properties
+func (c *Config) withDefaults(defaults map[string]string) *Config {
+	c.mx.RLock()
+
+	nc := &Config{
+		sections: slices.Clone(c.sections),
+		props:    slices.Clone(c.props),
+		data:     maps.Clone(c.data),
+		aliases:  c.aliases,
+		sources:  c.sources,
+		file:     c.file,
+		dropIns:  c.dropIns,
+	}
+
+	c.mx.RUnlock()
+
+	for prop, value := range defaults {
+		section, _, _ := strings.Cut(prop, _SYMBOL_DELIMITER)
+
+		if nc.data[section] == "" {
+			nc.data[section] = "!"
+			nc.sections = append(nc.sections, section)
+		}
+
+		if !slices.Contains(nc.props, prop) {
+			nc.props = append(nc.props, prop)
+		}
+
+		nc.data[prop] = value
+	}
+
+	return nc
+}
@@ -9,6 +9,10 @@ package knf
 
 import (
 	"fmt"
+	"os"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/events"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -89,6 +93,52 @@ func ExampleRead() {
 	fmt.Printf("Value from config: %s\n", cfg.GetS("service:user"))
 }
 
+func ExampleRead_include() {
+	// Configuration file can include other files using @include directive.
+	// Relative paths are resolved relative to the including file, glob
+	// patterns are supported:
+	//
+	//   [service]
+	//     user: nobody
+	//
+	//   @include conf.d/*.knf
+	//
+	// Properties from included files override previously defined properties.
+
+	cfg, err := Read("/path/to/your/config.knf")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Value from config: %s\n", cfg.GetS("service:user"))
+}
+
+func ExampleReadDir() {
+	// Read all *.knf files from directory and merge them in lexical order
+	cfg, err := ReadDir("/etc/myapp.d")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Value from config: %s\n", cfg.GetS("service:user"))
+}
+
+func ExampleReadWithDropIns() {
+	// Read /etc/myapp.knf and merge it with all *.knf files from /etc/myapp.d
+	cfg, err := ReadWithDropIns("/etc/myapp.knf", "")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Value from config: %s\n", cfg.GetS("service:user"))
+}
+
 func ExampleParse() {
 	cfg, err := Parse([]byte(`
 [service]
@@ -105,6 +155,34 @@ func ExampleParse() {
 	// Value from config: john
 }
 
+func ExampleParse_env() {
+	os.Setenv("SERVICE_USER", "john")
+
+	cfg, err := Parse([]byte(`
+[service]
+	user: ${SERVICE_USER}
+	port: ${SERVICE_PORT:-8080}
+	home: /home/{service:user}
+	escaped: $${SERVICE_USER}
+`))
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("User: %s\n", cfg.GetS("service:user"))
+	fmt.Printf("Port: %d\n", cfg.GetI("service:port"))
+	fmt.Printf("Home: %s\n", cfg.GetS("service:home"))
+	fmt.Printf("Escaped: %s\n", cfg.GetS("service:escaped"))
+
+	// Output:
+	// User: john
+	// Port: 8080
+	// Home: /home/john
+	// Escaped: ${SERVICE_USER}
+}
+
 func ExampleReload() {
 	err := Global("/path/to/your/config.knf")
 
@@ -356,6 +434,31 @@ func ExampleProps() {
 	}
 }
 
+func ExampleDecode() {
+	err := Global("/path/to/your/config.knf")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	type Config struct {
+		User  string `knf:"service:user" default:"nobody"`
+		Port  int    `knf:"service:port" default:"8080"`
+		Debug bool   `knf:"service:debug"`
+	}
+
+	config := &Config{}
+	errs := Decode(config)
+
+	if !errs.IsEmpty() {
+		fmt.Printf("Configuration errors:\n%s\n", errs.ErrorWithPrefix("  - "))
+		return
+	}
+
+	fmt.Printf("User: %s | Port: %d\n", config.User, config.Port)
+}
+
 func ExampleConfig_Merge() {
 	cfg1, _ := Parse([]byte(`
 [service]
@@ -947,6 +1050,71 @@ func ExampleConfig_Props() {
 	// 10: labels
 }
 
+func ExampleConfig_Decode() {
+	cfg, _ := Parse([]byte(`
+[service]
+	user: john
+	timeout: 30s
+
+[storage]
+	max-size: 10MB
+`))
+
+	// Validators which can be used in tags, in most cases you should use
+	// validators from knf/validators package
+	tagValidators := TagValidators{
+		"Set": {Func: func(config IConfig, prop string, _ any) error {
+			if config.GetS(prop) == "" {
+				return fmt.Errorf("Property %s must be set", prop)
+			}
+			return nil
+		}},
+	}
+
+	type Storage struct {
+		MaxSize uint64 `knf:"max-size,size"`
+		Path    string `knf:"path" default:"/var/lib/myapp"`
+	}
+
+	type Config struct {
+		User    string        `knf:"service:user" validate:"Set"`
+		Timeout time.Duration `knf:"service:timeout"`
+		Storage Storage       `knf:"storage"`
+	}
+
+	config := &Config{}
+	errs := cfg.Decode(config, tagValidators)
+
+	if !errs.IsEmpty() {
+		fmt.Printf("Configuration errors:\n%s\n", errs.ErrorWithPrefix("  - "))
+		return
+	}
+
+	fmt.Printf("User: %s\n", config.User)
+	fmt.Printf("Timeout: %v\n", config.Timeout)
+	fmt.Printf("Max size: %d\n", config.Storage.MaxSize)
+	fmt.Printf("Path: %s\n", config.Storage.Path)
+
+	// Output:
+	// User: john
+	// Timeout: 30s
+	// Max size: 10485760
+	// Path: /var/lib/myapp
+}
+
+func ExampleConfig_Source() {
+	cfg, err := ReadWithDropIns("/etc/myapp.knf", "")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if cfg.GetI("service:port") > 65535 {
+		fmt.Printf("Invalid port defined at %s\n", cfg.Source("service:port"))
+	}
+}
+
 func ExampleConfig_File() {
 	cfg, err := Read("/path/to/your/config.knf")
 
@@ -957,3 +1125,196 @@ func ExampleConfig_File() {
 
 	fmt.Printf("Path to config: %s\n", cfg.File())
 }
+
+func ExampleConfig_WriteTo() {
+	cfg, _ := Parse([]byte(`
+[service]
+	user: john
+	name: {service:user}-app
+`))
+
+	cfg.WriteTo(os.Stdout)
+
+	// Output:
+	// [service]
+	//   user: john
+	//   name: john-app
+}
+
+func ExampleEdit() {
+	e, err := Edit("/etc/myapp.knf")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	err = e.Set("service:port", "8080")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Check that configuration is still valid before saving
+	_, err = e.Config()
+
+	if err != nil {
+		fmt.Printf("Configuration is invalid: %v\n", err)
+		return
+	}
+
+	err = e.Save()
+
+	if err != nil {
+		fmt.Printf("Can't save configuration: %v\n", err)
+	}
+}
+
+func ExampleEditData() {
+	e := EditData([]byte(`# Service configuration
+[service]
+  # User name
+  user:    john
+  # Service port
+  port: 80
+
+# Deprecated settings
+[legacy]
+  mode: 1
+`))
+
+	e.Set("service:user", "bob")
+	e.Set("service:timeout", "30s")
+	e.Delete("service:port")
+	e.DeleteSection("legacy")
+
+	e.WriteTo(os.Stdout)
+
+	// Output:
+	// # Service configuration
+	// [service]
+	//   # User name
+	//   user:    bob
+	//   timeout: 30s
+}
+
+func ExampleEditor_Set() {
+	e := EditData([]byte("[service]\n  user: john\n"))
+
+	e.Set("service:user", "bob")
+	e.Set("service:port", "8080")
+	e.Set("log:level", "debug")
+
+	fmt.Print(string(e.Bytes()))
+
+	// Output:
+	// [service]
+	//   user: bob
+	//   port: 8080
+	//
+	// [log]
+	//   level: debug
+}
+
+func ExampleEditor_Delete() {
+	e := EditData([]byte("[service]\n  user: john\n  port: 80\n"))
+
+	fmt.Println(e.Delete("service:port"))
+	fmt.Print(string(e.Bytes()))
+
+	// Output:
+	// true
+	// [service]
+	//   user: john
+}
+
+func ExampleEditor_DeleteSection() {
+	e := EditData([]byte("[service]\n  user: john\n\n# Log configuration\n[log]\n  level: info\n"))
+
+	fmt.Println(e.DeleteSection("log"))
+	fmt.Print(string(e.Bytes()))
+
+	// Output:
+	// true
+	// [service]
+	//   user: john
+}
+
+func ExampleEditor_SaveAs() {
+	e := EditData([]byte("[service]\n  user: john\n"))
+
+	err := e.SaveAs("/etc/myapp.knf")
+
+	if err != nil {
+		fmt.Printf("Can't save configuration: %v\n", err)
+	}
+}
+
+func ExampleWatch() {
+	err := Global("/etc/myapp.knf")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	w, err := Watch(WatchOptions{
+		Signal: true,
+		Handler: func(event *ReloadEvent) {
+			if event.Err != nil {
+				fmt.Printf("Can't reload configuration: %v\n", event.Err)
+				return
+			}
+
+			fmt.Printf("Configuration reloaded, changed properties: %v\n", event.Changes)
+		},
+	})
+
+	if err != nil {
+		fmt.Printf("Can't start watcher: %v\n", err)
+		return
+	}
+
+	defer w.Stop()
+}
+
+func ExampleConfig_Watch() {
+	cfg, err := ReadWithDropIns("/etc/myapp.knf", "")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	dispatcher := events.NewDispatcher()
+
+	dispatcher.AddHandler(EV_RELOAD, func(payload any) {
+		event := payload.(*ReloadEvent)
+		fmt.Printf("Configuration reloaded, changed properties: %v\n", event.Changes)
+	})
+
+	dispatcher.AddHandler(EV_RELOAD_FAIL, func(payload any) {
+		event := payload.(*ReloadEvent)
+		fmt.Printf("Can't reload configuration: %v\n", event.Err)
+	})
+
+	w, err := cfg.Watch(WatchOptions{
+		Dispatcher: dispatcher,
+		Validators: Validators{
+			{"service:port", func(config IConfig, prop string, _ any) error {
+				if config.GetI(prop) <= 0 {
+					return fmt.Errorf("Property %s must be greater than 0", prop)
+				}
+				return nil
+			}, nil},
+		},
+	})
+
+	if err != nil {
+		fmt.Printf("Can't start watcher: %v\n", err)
+		return
+	}
+
+	defer w.Stop()
+}
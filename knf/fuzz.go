@@ -16,7 +16,7 @@ import (
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 func Fuzz(data []byte) int {
-	_, err := readData(bytes.NewReader(data))
+	_, err := readData(bytes.NewReader(data), false)
 
 	if err != nil {
 		return 0
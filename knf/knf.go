@@ -12,8 +12,9 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -34,6 +35,12 @@ const (
 	WEEK        = 7 * DAY
 )
 
+const (
+	// STRICT_ENV is option for returning an error if value contains macro with
+	// undefined environment variable without default value
+	STRICT_ENV Option = iota + 1
+)
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // IConfig is knf like configuration
@@ -89,11 +96,21 @@ type Config struct {
 	props    []string
 	data     map[string]string
 	aliases  map[string]string
+	sources  map[string]Source
 	file     string
+	dropIns  string
+
+	strictEnv bool
 
 	mx sync.RWMutex
 }
 
+// Source contains info about the file and the line where property is defined
+type Source struct {
+	File string // Path to file (empty if data was parsed using Parse)
+	Line int    // Line number
+}
+
 // Validator is configuration property validator struct
 type Validator struct {
 	Property string            // Property name
@@ -110,6 +127,9 @@ type PropertyValidator func(config IConfig, prop string, value any) error
 // DurationMod is type for duration modifier
 type DurationMod int64
 
+// Option is configuration reading option
+type Option uint8
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var (
@@ -126,8 +146,8 @@ var global atomic.Pointer[Config] // global is global configuration file
 
 // Global reads and parses configuration file
 // Global instance is accessible from any part of the code
-func Global(file string) error {
-	cfg, err := Read(file)
+func Global(file string, options ...Option) error {
+	cfg, err := Read(file, options...)
 
 	if err != nil {
 		return err
@@ -139,29 +159,62 @@ func Global(file string) error {
 }
 
 // Read reads and parses configuration file
-func Read(file string) (*Config, error) {
-	fd, err := os.OpenFile(path.Clean(file), os.O_RDONLY, 0)
+func Read(file string, options ...Option) (*Config, error) {
+	return readFile(file, nil, slices.Contains(options, STRICT_ENV))
+}
+
+// ReadDir reads all configuration files with .knf extension from given directory
+// and merges them in lexical order
+func ReadDir(dir string, options ...Option) (*Config, error) {
+	config, err := readDropIns(&Config{
+		data:      make(map[string]string),
+		sources:   make(map[string]Source),
+		strictEnv: slices.Contains(options, STRICT_ENV),
+	}, dir)
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer fd.Close()
+	if len(config.sections) == 0 {
+		return nil, fmt.Errorf("directory %q doesn't contain any configuration files", dir)
+	}
 
-	config, err := readData(fd)
+	config.dropIns = dir
+
+	return config, nil
+}
+
+// ReadWithDropIns reads and parses configuration file and merges it with all
+// drop-in configuration files with .knf extension from given directory in
+// lexical order. If dir is empty, drop-ins are read from the directory with
+// the same name as the file and .d extension (e.g. /etc/app.knf → /etc/app.d).
+// Missing drop-ins directory is ignored.
+func ReadWithDropIns(file, dir string, options ...Option) (*Config, error) {
+	if dir == "" {
+		dir = strings.TrimSuffix(file, filepath.Ext(file)) + ".d"
+	}
+
+	config, err := Read(file, options...)
 
 	if err != nil {
 		return nil, err
 	}
 
-	config.file = file
+	config, err = readDropIns(config, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config.dropIns = dir
 
 	return config, nil
 }
 
 // Parse parses data with KNF configuration
-func Parse(data []byte) (*Config, error) {
-	return readData(bytes.NewBuffer(data))
+func Parse(data []byte, options ...Option) (*Config, error) {
+	return readData(bytes.NewBuffer(data), slices.Contains(options, STRICT_ENV))
 }
 
 // Reload reloads global configuration file
@@ -509,6 +562,14 @@ func (c *Config) Merge(cfg *Config) error {
 		c.data[k] = v
 	}
 
+	if len(cfg.sources) != 0 && c.sources == nil {
+		c.sources = make(map[string]Source)
+	}
+
+	for k, v := range cfg.sources {
+		c.sources[k] = v
+	}
+
 SECTION_LOOP:
 	for _, ss := range cfg.sections {
 		for _, ts := range c.sections {
@@ -540,11 +601,7 @@ func (c *Config) Reload() (map[string]bool, error) {
 		return nil, ErrNilConfig
 	}
 
-	if c.file == "" {
-		return nil, ErrCantReload
-	}
-
-	nc, err := Read(c.file)
+	nc, err := c.readSources()
 
 	if err != nil {
 		return nil, err
@@ -559,8 +616,7 @@ func (c *Config) Reload() (map[string]bool, error) {
 		changes[prop] = c.data[prop] != nc.data[prop]
 	}
 
-	// Update current config data
-	c.data, c.sections, c.props = nc.data, nc.sections, nc.props
+	c.update(nc)
 
 	return changes, nil
 }
@@ -898,6 +954,29 @@ func (c *Config) File() string {
 	return c.file
 }
 
+// Source returns info about the file and the line where given property is
+// defined
+func (c *Config) Source(name string) Source {
+	if c == nil || !isValidPropName(name) {
+		return Source{}
+	}
+
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	name = strings.ToLower(name)
+
+	if c.aliases != nil && c.aliases[name] != "" {
+		src, ok := c.sources[c.aliases[name]]
+
+		if ok {
+			return src
+		}
+	}
+
+	return c.sources[name]
+}
+
 // Validate executes all given validators and
 // returns slice with validation errors
 func (c *Config) Validate(validators Validators) errors.Errors {
@@ -914,7 +993,7 @@ func (c *Config) Validate(validators Validators) errors.Errors {
 		err := v.Func(c, v.Property, v.Value)
 
 		if err != nil {
-			errs = append(errs, err)
+			errs = append(errs, &errors.KeyError{Key: v.Property, Err: err})
 		}
 	}
 
@@ -923,6 +1002,64 @@ func (c *Config) Validate(validators Validators) errors.Errors {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// String returns source info as "file:line"
+func (s Source) String() string {
+	if s.File == "" {
+		return "line " + strconv.Itoa(s.Line)
+	}
+
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readDropIns reads all configuration files with .knf extension from given
+// directory and merges them into configuration in lexical order. Drop-ins are
+// parsed on top of given configuration, so macros can reference properties
+// defined in the main file or in previous drop-ins.
+func readDropIns(config *Config, dir string) (*Config, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.knf"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		err = readFileInto(config, file, nil)
+
+		if err != nil {
+			return nil, fmt.Errorf("can't read drop-in file %q: %w", file, err)
+		}
+	}
+
+	return config, nil
+}
+
+// readSources reads configuration from the same sources as current configuration
+func (c *Config) readSources() (*Config, error) {
+	var options []Option
+
+	if c.strictEnv {
+		options = append(options, STRICT_ENV)
+	}
+
+	switch {
+	case c.file != "" && c.dropIns != "":
+		return ReadWithDropIns(c.file, c.dropIns, options...)
+	case c.file != "":
+		return Read(c.file, options...)
+	case c.dropIns != "":
+		return ReadDir(c.dropIns, options...)
+	}
+
+	return nil, ErrCantReload
+}
+
+// update replaces current configuration data with data from given configuration
+func (c *Config) update(nc *Config) {
+	c.data, c.sections, c.props, c.sources = nc.data, nc.sections, nc.props, nc.sources
+}
+
 // getValue returns property value from the storage
 func (c *Config) getValue(propName string) string {
 	if c == nil {
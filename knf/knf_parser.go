@@ -11,7 +11,10 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -24,25 +27,94 @@ const (
 	_SYMBOL_DELIMITER     = ":"
 	_SYMBOL_MACRO_START   = "{"
 	_SYMBOL_MACRO_END     = "}"
+	_SYMBOL_INCLUDE       = "@include"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// macroRE is a regexp for extracting macro
-var macroRE = regexp.MustCompile(`\{([\w\-]+):([\w\-]+)\}`)
+// macroRE is a regexp for extracting macro. It matches escaped environment
+// variable macro ($${VAR}), environment variable macro with optional default
+// value (${VAR} or ${VAR:-default}), escaped property macro ({{section:prop}})
+// and property macro ({section:prop}).
+var macroRE = regexp.MustCompile(
+	`\$\$\{[^}]*\}|\$\{([A-Za-z_]\w*)(:-[^}]*)?\}|\{\{[\w\-]+:[\w\-]+\}\}|\{([\w\-]+):([\w\-]+)\}`,
+)
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // readData reads data from given reader
-func readData(r io.Reader) (*Config, error) {
+func readData(r io.Reader, strictEnv bool) (*Config, error) {
+	return parseData(r, "", nil, strictEnv)
+}
+
+// readFile reads and parses configuration file. Stack contains paths of files
+// which are currently being read and used for include cycle detection.
+func readFile(file string, stack []string, strictEnv bool) (*Config, error) {
+	fd, err := os.OpenFile(filepath.Clean(file), os.O_RDONLY, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	config, err := parseData(fd, file, stack, strictEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config.file = file
+
+	return config, nil
+}
+
+// readFileInto reads configuration file (drop-in or included file) and adds its
+// data to given configuration. Data from given configuration can be used in
+// property macros. Empty files and files with only comments are allowed.
+func readFileInto(config *Config, file string, stack []string) error {
+	fd, err := os.OpenFile(filepath.Clean(file), os.O_RDONLY, 0)
+
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	_, err = parseDataInto(config, fd, file, stack)
+
+	return err
+}
+
+// parseData parses data from given reader
+func parseData(r io.Reader, file string, stack []string, strictEnv bool) (*Config, error) {
 	config := &Config{
-		data: make(map[string]string),
+		data:      make(map[string]string),
+		sources:   make(map[string]Source),
+		strictEnv: strictEnv,
 	}
 
+	isDataRead, err := parseDataInto(config, r, file, stack)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDataRead {
+		return nil, fmt.Errorf("configuration file doesn't contain any valid data")
+	}
+
+	return config, nil
+}
+
+// parseDataInto parses data from given reader and adds it to given configuration.
+// It returns false if there is no data except comments and empty lines.
+func parseDataInto(config *Config, r io.Reader, file string, stack []string) (bool, error) {
 	var isDataRead bool
 	var section string
 	var lineNum int
 
+	defined := make(map[string]bool)
 	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
@@ -55,39 +127,115 @@ func readData(r io.Reader) (*Config, error) {
 
 		isDataRead = true
 
+		if isIncludeDirective(line) {
+			err := includeFiles(config, line, file, stack)
+
+			if err != nil {
+				return false, fmt.Errorf("error at line %d: %w", lineNum, err)
+			}
+
+			continue
+		}
+
 		if strings.HasPrefix(line, _SYMBOL_SECTION_START) &&
 			strings.HasSuffix(line, _SYMBOL_SECTION_END) {
 			section = line[1 : len(line)-1]
-			config.data[strings.ToLower(section)] = "!"
-			config.sections = append(config.sections, section)
+
+			if !slices.Contains(config.sections, section) {
+				config.data[strings.ToLower(section)] = "!"
+				config.sections = append(config.sections, section)
+			}
+
 			continue
 		}
 
 		if section == "" {
-			return nil, fmt.Errorf("error at line %d: data defined before section", lineNum)
+			return false, fmt.Errorf("error at line %d: data defined before section", lineNum)
 		}
 
 		propName, propValue, err := parseProperty(line, config)
 
 		if err != nil {
-			return nil, fmt.Errorf("error at line %d: %w", lineNum, err)
+			return false, fmt.Errorf("error at line %d: %w", lineNum, err)
 		}
 
 		fullPropName := Q(section, propName)
 
-		if config.Has(fullPropName) {
-			return nil, fmt.Errorf("error at line %d: property %q defined more than once", lineNum, propName)
+		if defined[fullPropName] && config.Has(fullPropName) {
+			return false, fmt.Errorf("error at line %d: property %q defined more than once", lineNum, propName)
 		}
 
-		config.props = append(config.props, fullPropName)
+		if !slices.Contains(config.props, fullPropName) {
+			config.props = append(config.props, fullPropName)
+		}
+
+		defined[fullPropName] = true
 		config.data[fullPropName] = propValue
+		config.sources[fullPropName] = Source{file, lineNum}
 	}
 
-	if !isDataRead {
-		return nil, fmt.Errorf("configuration file doesn't contain any valid data")
+	return isDataRead, scanner.Err()
+}
+
+// isIncludeDirective returns true if given line is include directive
+func isIncludeDirective(line string) bool {
+	directive, _, _ := strings.Cut(line, " ")
+	return directive == _SYMBOL_INCLUDE
+}
+
+// includeFiles reads files from include directive and merges them into
+// configuration
+func includeFiles(config *Config, line, file string, stack []string) error {
+	pattern := strings.Trim(strings.TrimPrefix(line, _SYMBOL_INCLUDE), " \t")
+
+	if pattern == "" {
+		return fmt.Errorf("include path is empty")
+	}
+
+	if !filepath.IsAbs(pattern) && file != "" {
+		pattern = filepath.Join(filepath.Dir(file), pattern)
+	}
+
+	files := []string{pattern}
+
+	if strings.ContainsAny(pattern, "*?[") {
+		var err error
+
+		files, err = filepath.Glob(pattern)
+
+		if err != nil {
+			return fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+	}
+
+	if file != "" {
+		stack = append(slices.Clip(stack), getAbsPath(file))
+	}
+
+	for _, f := range files {
+		if slices.Contains(stack, getAbsPath(f)) {
+			return fmt.Errorf("include cycle detected (%s)", f)
+		}
+
+		err := readFileInto(config, f, stack)
+
+		if err != nil {
+			return fmt.Errorf("can't include %q: %w", f, err)
+		}
 	}
 
-	return config, scanner.Err()
+	return nil
+}
+
+// getAbsPath returns absolute path to file
+func getAbsPath(file string) string {
+	absPath, err := filepath.Abs(file)
+
+	if err != nil {
+		return filepath.Clean(file)
+	}
+
+	return absPath
 }
 
 // parseProperty parses line with property name and value
@@ -118,18 +266,75 @@ func parseProperty(line string, config *Config) (string, string, error) {
 
 // evalMacros evaluates all macro in given string
 func evalMacros(value string, config *Config) (string, error) {
-	macros := macroRE.FindAllStringSubmatch(value, -1)
+	var buf strings.Builder
+	var last int
+
+	for _, m := range macroRE.FindAllStringSubmatchIndex(value, -1) {
+		buf.WriteString(value[last:m[0]])
+		last = m[1]
+
+		full := value[m[0]:m[1]]
+
+		switch {
+		case strings.HasPrefix(full, "$$"):
+			// Escaped environment variable macro
+			buf.WriteString(full[1:])
+
+		case strings.HasPrefix(full, "{{"):
+			// Escaped property macro
+			buf.WriteString(full[1 : len(full)-1])
+
+		case m[2] != -1:
+			envVal, err := evalEnvMacro(
+				value[m[2]:m[3]], getSubmatch(value, m, 4), config.strictEnv,
+			)
 
-	for _, macro := range macros {
-		full, section, prop := macro[0], macro[1], macro[2]
-		propVal, exists := config.data[Q(section, prop)]
+			if err != nil {
+				return "", err
+			}
 
-		if !exists {
-			return "", fmt.Errorf("unknown property %s", full)
+			buf.WriteString(envVal)
+
+		default:
+			propVal, exists := config.data[Q(value[m[6]:m[7]], value[m[8]:m[9]])]
+
+			if !exists {
+				return "", fmt.Errorf("unknown property %s", full)
+			}
+
+			buf.WriteString(propVal)
 		}
+	}
+
+	buf.WriteString(value[last:])
+
+	return buf.String(), nil
+}
+
+// evalEnvMacro returns value of environment variable or default value. In strict
+// mode, it returns an error if variable is not defined and there is no default
+// value.
+func evalEnvMacro(name, defValue string, strict bool) (string, error) {
+	envVal, exists := os.LookupEnv(name)
+
+	switch {
+	case envVal != "":
+		return envVal, nil
+	case defValue != "":
+		return strings.TrimPrefix(defValue, ":-"), nil
+	case !exists && strict:
+		return "", fmt.Errorf("environment variable %q is not defined", name)
+	}
+
+	return "", nil
+}
 
-		value = strings.ReplaceAll(value, full, propVal)
+// getSubmatch returns submatch with given index or empty string if submatch
+// is not found
+func getSubmatch(value string, m []int, index int) string {
+	if m[index] == -1 {
+		return ""
 	}
 
-	return value, nil
+	return value[m[index]:m[index+1]]
 }
@@ -8,14 +8,18 @@ package knf
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/essentialkaos/ek/v14/errors"
+	"github.com/essentialkaos/ek/v14/events"
 
 	check "github.com/essentialkaos/check"
 )
@@ -614,6 +618,7 @@ func (s *KNFSuite) TestNil(c *check.C) {
 	c.Assert(nilConf.Sections(), check.HasLen, 0)
 	c.Assert(nilConf.Props("formatting"), check.HasLen, 0)
 	c.Assert(nilConf.File(), check.Equals, "")
+	c.Assert(nilConf.Source("formatting:test1"), check.Equals, Source{})
 	c.Assert(nilConf.Alias("test:test", "test:test"), check.NotNil)
 
 	_, err := nilConf.Reload()
@@ -714,9 +719,24 @@ func (s *KNFSuite) TestSimpleValidator(c *check.C) {
 		{"string:test2", simpleValidator, nil},
 	})
 
+	validators = validators.Add(Validators{
+		{"string:test1", func(config IConfig, prop string, value any) error {
+			return fmt.Errorf("ERROR1")
+		}, nil},
+	})
+
 	errs := Validate(validators)
 
-	c.Assert(errs, check.HasLen, 2)
+	c.Assert(errs, check.HasLen, 3)
+	c.Assert(errs[0].Error(), check.Equals, "ERROR")
+	c.Assert(errs.Keys(), check.DeepEquals, []string{"string:test2", "string:test1"})
+
+	groups := errs.Group()
+
+	c.Assert(groups, check.HasLen, 2)
+	c.Assert(groups["string:test2"], check.HasLen, 2)
+	c.Assert(groups["string:test1"], check.HasLen, 1)
+	c.Assert(groups["string:test1"][0].Error(), check.Equals, "ERROR1")
 }
 
 func (s *KNFSuite) TestKNFParserExceptions(c *check.C) {
@@ -725,7 +745,7 @@ func (s *KNFSuite) TestKNFParserExceptions(c *check.C) {
 		ABCD
 	`)
 
-	_, err := readData(r)
+	_, err := readData(r, false)
 	c.Assert(err.Error(), check.Equals, `error at line 3: property must have ":" as a delimiter`)
 
 	r = strings.NewReader(`
@@ -734,7 +754,7 @@ func (s *KNFSuite) TestKNFParserExceptions(c *check.C) {
 		A: 2
 	`)
 
-	_, err = readData(r)
+	_, err = readData(r, false)
 	c.Assert(err.Error(), check.Equals, `error at line 4: property "A" defined more than once`)
 
 	r = strings.NewReader(`
@@ -742,10 +762,853 @@ func (s *KNFSuite) TestKNFParserExceptions(c *check.C) {
 		A: {abcd:test}
 	`)
 
-	_, err = readData(r)
+	_, err = readData(r, false)
 	c.Assert(err.Error(), check.Equals, "error at line 3: unknown property {abcd:test}")
 }
 
+func (s *KNFSuite) TestEnvMacros(c *check.C) {
+	os.Setenv("EK_KNF_TEST_USER", "bob")
+	os.Setenv("EK_KNF_TEST_EMPTY", "")
+	os.Unsetenv("EK_KNF_TEST_UNKNOWN")
+
+	defer os.Unsetenv("EK_KNF_TEST_USER")
+	defer os.Unsetenv("EK_KNF_TEST_EMPTY")
+
+	cfg, err := Parse([]byte(`
+[main]
+  user: ${EK_KNF_TEST_USER}
+  home: /home/${EK_KNF_TEST_USER}/{main:dir}
+  dir: data
+  port: ${EK_KNF_TEST_UNKNOWN:-8080}
+  host: ${EK_KNF_TEST_EMPTY:-localhost}
+  empty: ${EK_KNF_TEST_UNKNOWN}
+  default: ${EK_KNF_TEST_UNKNOWN:-}
+  escaped: $${EK_KNF_TEST_USER} $${EK_KNF_TEST_UNKNOWN:-test}
+  password: pa$$word
+  mixed: {main:dir}-${EK_KNF_TEST_USER}-${EK_KNF_TEST_UNKNOWN:-1}
+`))
+
+	c.Assert(err, check.NotNil)
+	c.Assert(err.Error(), check.Equals, "error at line 4: unknown property {main:dir}")
+
+	cfg, err = Parse([]byte(`
+[main]
+  user: ${EK_KNF_TEST_USER}
+  dir: data
+  home: /home/${EK_KNF_TEST_USER}/{main:dir}
+  port: ${EK_KNF_TEST_UNKNOWN:-8080}
+  host: ${EK_KNF_TEST_EMPTY:-localhost}
+  empty: ${EK_KNF_TEST_UNKNOWN}
+  default: ${EK_KNF_TEST_UNKNOWN:-}
+  escaped: $${EK_KNF_TEST_USER} $${EK_KNF_TEST_UNKNOWN:-test}
+  password: pa$$word
+  mixed: {main:dir}-${EK_KNF_TEST_USER}-${EK_KNF_TEST_UNKNOWN:-1}
+`))
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:user"), check.Equals, "bob")
+	c.Assert(cfg.GetS("main:home"), check.Equals, "/home/bob/data")
+	c.Assert(cfg.GetI("main:port"), check.Equals, 8080)
+	c.Assert(cfg.GetS("main:host"), check.Equals, "localhost")
+	c.Assert(cfg.GetS("main:empty"), check.Equals, "")
+	c.Assert(cfg.GetS("main:default"), check.Equals, "")
+	c.Assert(cfg.GetS("main:escaped"), check.Equals, "${EK_KNF_TEST_USER} ${EK_KNF_TEST_UNKNOWN:-test}")
+	c.Assert(cfg.GetS("main:password"), check.Equals, "pa$$word")
+	c.Assert(cfg.GetS("main:mixed"), check.Equals, "data-bob-1")
+
+	_, err = Parse([]byte("[main]\n  empty: ${EK_KNF_TEST_EMPTY}\n  default: ${EK_KNF_TEST_UNKNOWN:-}\n"), STRICT_ENV)
+	c.Assert(err, check.IsNil)
+
+	_, err = Parse([]byte("[main]\n  user: ${EK_KNF_TEST_USER}\n  port: ${EK_KNF_TEST_UNKNOWN}\n"), STRICT_ENV)
+	c.Assert(err, check.NotNil)
+	c.Assert(err.Error(), check.Equals, `error at line 3: environment variable "EK_KNF_TEST_UNKNOWN" is not defined`)
+
+	tmpDir := c.MkDir()
+	configFile := tmpDir + "/strict.knf"
+
+	os.WriteFile(configFile, []byte("[main]\n  user: ${EK_KNF_TEST_USER}\n"), 0644)
+
+	cfg, err = Read(configFile, STRICT_ENV)
+	c.Assert(err, check.IsNil)
+
+	os.WriteFile(configFile, []byte("[main]\n  user: ${EK_KNF_TEST_UNKNOWN}\n"), 0644)
+
+	_, err = cfg.Reload()
+	c.Assert(err, check.NotNil)
+	c.Assert(cfg.GetS("main:user"), check.Equals, "bob")
+
+	_, err = ReadDir(tmpDir, STRICT_ENV)
+	c.Assert(err, check.NotNil)
+
+	_, err = ReadDir(tmpDir)
+	c.Assert(err, check.IsNil)
+}
+
+func (s *KNFSuite) TestDecode(c *check.C) {
+	type LogConfig struct {
+		Dir   string      `knf:"dir" default:"/var/log"`
+		Level string      `knf:"level" validate:"Set"`
+		Mode  os.FileMode `knf:"mode"`
+	}
+
+	type AppConfig struct {
+		Name     string         `knf:"main:name" validate:"Set,HasPrefix(my)"`
+		Port     int            `knf:"main:port" validate:"Less(1024)"`
+		Workers  uint8          `knf:"main:workers" default:"4"`
+		Ratio    float64        `knf:"main:ratio"`
+		Debug    bool           `knf:"main:debug"`
+		Timeout  time.Duration  `knf:"main:timeout"`
+		MaxSize  uint64         `knf:"main:max-size,size"`
+		Count    int64          `knf:"main:count"`
+		Hosts    []string       `knf:"main:hosts"`
+		TZ       *time.Location `knf:"main:tz"`
+		Started  time.Time      `knf:"main:started"`
+		Unknown  string         `knf:"main:unknown"`
+		Preset   string         `knf:"main:preset"`
+		Ignored  string         `knf:"-"`
+		NoTag    string
+		Log      LogConfig `knf:"log"`
+		Embedded struct {
+			User string `knf:"main:user"`
+		}
+
+		private string `knf:"main:name"`
+	}
+
+	cfg, err := Parse([]byte(`
+[main]
+  name: myapp
+  port: 8080
+  ratio: 0.5
+  debug: true
+  timeout: 3m
+  max-size: 2mb
+  count: 1000000000000
+  hosts: a.example.com, b.example.com
+  tz: Europe/Zurich
+  started: 1709629048
+  user: nobody
+
+[log]
+  level: info
+  mode: 0640
+`))
+
+	c.Assert(err, check.IsNil)
+
+	validators := TagValidators{
+		"Set":       {Func: testValidatorSet},
+		"HasPrefix": {Func: testValidatorHasPrefix},
+		"Less": {Func: testValidatorLess, Parse: func(arg string) (any, error) {
+			return strconv.Atoi(arg)
+		}},
+	}
+
+	var conf AppConfig
+
+	conf.Preset = "test"
+
+	errs := cfg.Decode(&conf, validators)
+
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].Error(), check.Equals, "property main:port must be less than 1024")
+	c.Assert(errors.KeyOf(errs[0]), check.Equals, "main:port")
+
+	c.Assert(conf.Name, check.Equals, "myapp")
+	c.Assert(conf.Port, check.Equals, 8080)
+	c.Assert(conf.Workers, check.Equals, uint8(4))
+	c.Assert(conf.Ratio, check.Equals, 0.5)
+	c.Assert(conf.Debug, check.Equals, true)
+	c.Assert(conf.Timeout, check.Equals, 3*time.Minute)
+	c.Assert(conf.MaxSize, check.Equals, uint64(2*1024*1024))
+	c.Assert(conf.Count, check.Equals, int64(1000000000000))
+	c.Assert(conf.Hosts, check.DeepEquals, []string{"a.example.com", "b.example.com"})
+	c.Assert(conf.TZ.String(), check.Equals, "Europe/Zurich")
+	c.Assert(conf.Started.Unix(), check.Equals, int64(1709629048))
+	c.Assert(conf.Unknown, check.Equals, "")
+	c.Assert(conf.Preset, check.Equals, "test")
+	c.Assert(conf.Log.Dir, check.Equals, "/var/log")
+	c.Assert(conf.Log.Level, check.Equals, "info")
+	c.Assert(conf.Log.Mode, check.Equals, os.FileMode(0640))
+	c.Assert(conf.Embedded.User, check.Equals, "nobody")
+	c.Assert(conf.private, check.Equals, "")
+
+	global.Store(cfg)
+	c.Assert(Decode(&conf, validators), check.HasLen, 1)
+	global.Store(nil)
+	c.Assert(Decode(&conf), check.DeepEquals, errors.Errors{ErrNilConfig})
+
+	var nilConf *Config
+
+	c.Assert(nilConf.Decode(&conf), check.DeepEquals, errors.Errors{ErrNilConfig})
+	c.Assert(cfg.Decode(nil), check.DeepEquals, errors.Errors{ErrInvalidTarget})
+	c.Assert(cfg.Decode(conf), check.DeepEquals, errors.Errors{ErrInvalidTarget})
+	c.Assert(cfg.Decode(&conf.Port), check.DeepEquals, errors.Errors{ErrInvalidTarget})
+}
+
+func (s *KNFSuite) TestDecodeDefaults(c *check.C) {
+	type AppConfig struct {
+		Port    int    `knf:"main:port" default:"8080" validate:"Set,Greater(1024)"`
+		Workers int    `knf:"main:workers" default:"2" validate:"Set,Greater(4)"`
+		Name    string `knf:"main:name" default:"app" validate:"Set"`
+		Dir     string `knf:"storage:dir" default:"/srv" validate:"Set,HasPrefix(/srv)"`
+		User    string `knf:"main:user" validate:"Set"`
+	}
+
+	cfg, err := Parse([]byte("[main]\n  name: test\n"))
+
+	c.Assert(err, check.IsNil)
+
+	validators := TagValidators{
+		"Set":       {Func: testValidatorSet},
+		"HasPrefix": {Func: testValidatorHasPrefix},
+		"Greater": {Func: testValidatorGreater, Parse: func(arg string) (any, error) {
+			return strconv.Atoi(arg)
+		}},
+	}
+
+	var conf AppConfig
+
+	errs := cfg.Decode(&conf, validators)
+
+	c.Assert(errs, check.HasLen, 2)
+	c.Assert(errs[0].Error(), check.Equals, "property main:workers must be greater than 4")
+	c.Assert(errs[1].Error(), check.Equals, "property main:user must be set")
+
+	c.Assert(conf.Port, check.Equals, 8080)
+	c.Assert(conf.Workers, check.Equals, 2)
+	c.Assert(conf.Name, check.Equals, "test")
+	c.Assert(conf.Dir, check.Equals, "/srv")
+
+	c.Assert(cfg.Has("main:port"), check.Equals, false)
+	c.Assert(cfg.HasSection("storage"), check.Equals, false)
+}
+
+func (s *KNFSuite) TestDecodeErrors(c *check.C) {
+	type AppConfig struct {
+		Port    int8           `knf:"main:port" validate:"Set,Unknown,Less(abc),Less(1"`
+		Name    string         `knf:"main"`
+		Map     map[string]int `knf:"main:map"`
+		Section struct {
+			Value string `knf:"value"`
+		} `knf:"main:section"`
+		Size uint8 `knf:"main:size,size"`
+	}
+
+	cfg, err := Parse([]byte("[main]\n  port: 1000\n  map: test\n  size: 1kb\n"))
+
+	c.Assert(err, check.IsNil)
+
+	validators := TagValidators{
+		"Set": {Func: testValidatorSet},
+		"Less": {Func: testValidatorLess, Parse: func(arg string) (any, error) {
+			return strconv.Atoi(arg)
+		}},
+	}
+
+	var conf AppConfig
+
+	errs := cfg.Decode(&conf, validators)
+
+	c.Assert(errs, check.HasLen, 8)
+	c.Assert(errs[0], check.ErrorMatches, `field Port: value 1000 overflows int8`)
+	c.Assert(errs[1], check.ErrorMatches, `field Port: unknown validator "Unknown"`)
+	c.Assert(errs[2], check.ErrorMatches, `field Port: invalid argument for validator "Less": .*`)
+	c.Assert(errs[3], check.ErrorMatches, `field Port: invalid validator "Less\(1"`)
+	c.Assert(errs[4], check.ErrorMatches, `field Name: invalid property name "main"`)
+	c.Assert(errs[5], check.ErrorMatches, `field Map: unsupported type map\[string\]int`)
+	c.Assert(errs[6], check.ErrorMatches, `field Section: struct can't be decoded from property "main:section"`)
+	c.Assert(errs[7], check.ErrorMatches, `field Size: value 1024 overflows uint8`)
+}
+
+func (s *KNFSuite) TestInclude(c *check.C) {
+	dir := c.MkDir()
+
+	c.Assert(os.MkdirAll(dir+"/app.d", 0755), check.IsNil)
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  name: app\n  port: 80\n\n@include app.d/*.knf\n\n[log]\n  level: {main:port}\n")
+	writeTestFile(c, dir+"/app.d/20-port.knf", "[main]\n  port: 8080\n")
+	writeTestFile(c, dir+"/app.d/10-port.knf", "[main]\n  port: 8000\n  user: nobody\n@include "+dir+"/common.knf\n")
+	writeTestFile(c, dir+"/common.knf", "[common]\n  path: /srv\n")
+
+	cfg, err := Read(dir + "/app.knf")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:name"), check.Equals, "app")
+	c.Assert(cfg.GetI("main:port"), check.Equals, 8080)
+	c.Assert(cfg.GetS("main:user"), check.Equals, "nobody")
+	c.Assert(cfg.GetS("common:path"), check.Equals, "/srv")
+	c.Assert(cfg.GetS("log:level"), check.Equals, "8080")
+	c.Assert(cfg.Sections(), check.DeepEquals, []string{"main", "common", "log"})
+	c.Assert(cfg.Props("main"), check.DeepEquals, []string{"name", "port", "user"})
+	c.Assert(cfg.Source("main:name"), check.Equals, Source{dir + "/app.knf", 2})
+	c.Assert(cfg.Source("main:port"), check.Equals, Source{dir + "/app.d/20-port.knf", 2})
+	c.Assert(cfg.Source("common:path").String(), check.Equals, dir+"/common.knf:2")
+	c.Assert(cfg.Source("main:unknown"), check.Equals, Source{})
+	c.Assert(cfg.Source("unknown"), check.Equals, Source{})
+
+	c.Assert(cfg.Alias("main:port", "main:http-port"), check.IsNil)
+	c.Assert(cfg.Source("main:http-port").Line, check.Equals, 2)
+
+	cfg, err = Parse([]byte("[main]\n  name: app\n"))
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.Source("main:name").String(), check.Equals, "line 2")
+
+	writeTestFile(c, dir+"/empty.knf", "@include app.d/*.conf\n")
+
+	_, err = Read(dir + "/empty.knf")
+	c.Assert(err, check.IsNil)
+
+	writeTestFile(c, dir+"/cycle1.knf", "[main]\n  a: 1\n@include cycle2.knf\n")
+	writeTestFile(c, dir+"/cycle2.knf", "[main]\n  b: 1\n@include cycle1.knf\n")
+
+	_, err = Read(dir + "/cycle1.knf")
+	c.Assert(err, check.ErrorMatches, `error at line 3: can't include ".*/cycle2.knf": error at line 3: include cycle detected \(.*/cycle1.knf\)`)
+
+	writeTestFile(c, dir+"/self.knf", "@include self.knf\n")
+
+	_, err = Read(dir + "/self.knf")
+	c.Assert(err, check.ErrorMatches, `error at line 1: include cycle detected \(.*/self.knf\)`)
+
+	writeTestFile(c, dir+"/missing.knf", "[main]\n  a: 1\n@include missing.d/main.knf\n")
+
+	_, err = Read(dir + "/missing.knf")
+	c.Assert(err, check.ErrorMatches, `error at line 3: can't include ".*/missing.d/main.knf": open .*: no such file or directory`)
+
+	writeTestFile(c, dir+"/broken.knf", "@include\n")
+
+	_, err = Read(dir + "/broken.knf")
+	c.Assert(err, check.ErrorMatches, `error at line 1: include path is empty`)
+
+	writeTestFile(c, dir+"/pattern.knf", "@include [\n")
+
+	_, err = Read(dir + "/pattern.knf")
+	c.Assert(err, check.ErrorMatches, `error at line 1: invalid include pattern .*`)
+
+	writeTestFile(c, dir+"/malformed.knf", "@include app.d/*.knf\n@include common.knf\n@include malformed.d/*.knf\n")
+	c.Assert(os.MkdirAll(dir+"/malformed.d", 0755), check.IsNil)
+	writeTestFile(c, dir+"/malformed.d/00.knf", "test: 1\n")
+
+	_, err = Read(dir + "/malformed.knf")
+	c.Assert(err, check.ErrorMatches, `error at line 3: can't include ".*/malformed.d/00.knf": error at line 1: data defined before section`)
+}
+
+func (s *KNFSuite) TestDropIns(c *check.C) {
+	dir := c.MkDir()
+
+	c.Assert(os.MkdirAll(dir+"/app.d", 0755), check.IsNil)
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  name: app\n  port: 80\n")
+	writeTestFile(c, dir+"/app.d/20-port.knf", "[main]\n  port: 8080\n")
+	writeTestFile(c, dir+"/app.d/10-port.knf", "[main]\n  port: 8000\n  user: nobody\n")
+	writeTestFile(c, dir+"/app.d/README", "test")
+
+	cfg, err := ReadWithDropIns(dir+"/app.knf", "")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.File(), check.Equals, dir+"/app.knf")
+	c.Assert(cfg.GetI("main:port"), check.Equals, 8080)
+	c.Assert(cfg.GetS("main:user"), check.Equals, "nobody")
+	c.Assert(cfg.Source("main:user"), check.Equals, Source{dir + "/app.d/10-port.knf", 3})
+
+	writeTestFile(c, dir+"/app.d/30-port.knf", "[main]\n  port: 9000\n")
+
+	changes, err := cfg.Reload()
+
+	c.Assert(err, check.IsNil)
+	c.Assert(changes["main:port"], check.Equals, true)
+	c.Assert(changes["main:name"], check.Equals, false)
+	c.Assert(cfg.GetI("main:port"), check.Equals, 9000)
+	c.Assert(cfg.Source("main:port").File, check.Equals, dir+"/app.d/30-port.knf")
+
+	cfg, err = ReadWithDropIns(dir+"/app.knf", dir+"/unknown.d")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetI("main:port"), check.Equals, 80)
+
+	cfg, err = ReadDir(dir + "/app.d")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.File(), check.Equals, "")
+	c.Assert(cfg.GetI("main:port"), check.Equals, 9000)
+
+	_, err = cfg.Reload()
+	c.Assert(err, check.IsNil)
+
+	_, err = ReadDir(dir + "/unknown.d")
+	c.Assert(err, check.ErrorMatches, `directory ".*/unknown.d" doesn't contain any configuration files`)
+
+	_, err = ReadWithDropIns(dir+"/unknown.knf", "")
+	c.Assert(err, check.NotNil)
+
+	writeTestFile(c, dir+"/app.d/40-broken.knf", "port: 1\n")
+
+	_, err = ReadWithDropIns(dir+"/app.knf", "")
+	c.Assert(err, check.ErrorMatches, `can't read drop-in file ".*/app.d/40-broken.knf": error at line 1: data defined before section`)
+
+	_, err = ReadDir(dir + "/app.d")
+	c.Assert(err, check.NotNil)
+
+	_, err = ReadDir("[")
+	c.Assert(err, check.NotNil)
+}
+
+func (s *KNFSuite) TestDropInsMacros(c *check.C) {
+	dir := c.MkDir()
+
+	c.Assert(os.MkdirAll(dir+"/app.d", 0755), check.IsNil)
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  name: app\n  dir: /srv\n")
+	writeTestFile(c, dir+"/app.d/10-user.knf", "[main]\n  user: {main:name}-user\n")
+	writeTestFile(c, dir+"/app.d/20-data.knf", "[data]\n  dir: {main:dir}/{main:user}\n")
+
+	cfg, err := ReadWithDropIns(dir+"/app.knf", "")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:user"), check.Equals, "app-user")
+	c.Assert(cfg.GetS("data:dir"), check.Equals, "/srv/app-user")
+	c.Assert(cfg.Sections(), check.DeepEquals, []string{"main", "data"})
+	c.Assert(cfg.Source("data:dir"), check.Equals, Source{dir + "/app.d/20-data.knf", 2})
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  name: test\n  dir: /opt\n")
+
+	changes, err := cfg.Reload()
+
+	c.Assert(err, check.IsNil)
+	c.Assert(changes["data:dir"], check.Equals, true)
+	c.Assert(cfg.GetS("data:dir"), check.Equals, "/opt/test-user")
+
+	_, err = ReadDir(dir + "/app.d")
+	c.Assert(err, check.ErrorMatches, `can't read drop-in file ".*/app.d/10-user.knf": error at line 2: unknown property \{main:name\}`)
+}
+
+func (s *KNFSuite) TestDropInsEmpty(c *check.C) {
+	dir := c.MkDir()
+
+	c.Assert(os.MkdirAll(dir+"/app.d", 0755), check.IsNil)
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  name: app\n\n@include common.knf\n")
+	writeTestFile(c, dir+"/common.knf", "# [common]\n#   path: /srv\n")
+	writeTestFile(c, dir+"/app.d/10-example.knf", "# Example drop-in\n#\n# [main]\n#   name: test\n")
+	writeTestFile(c, dir+"/app.d/20-empty.knf", "")
+	writeTestFile(c, dir+"/app.d/30-port.knf", "[main]\n  port: 80\n")
+
+	cfg, err := ReadWithDropIns(dir+"/app.knf", "")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:name"), check.Equals, "app")
+	c.Assert(cfg.GetI("main:port"), check.Equals, 80)
+
+	_, err = cfg.Reload()
+	c.Assert(err, check.IsNil)
+
+	cfg, err = ReadDir(dir + "/app.d")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetI("main:port"), check.Equals, 80)
+
+	_, err = Read(dir + "/common.knf")
+	c.Assert(err, check.ErrorMatches, "configuration file doesn't contain any valid data")
+}
+
+func (s *KNFSuite) TestWriter(c *check.C) {
+	cfg, err := Parse([]byte("[main]\n  user: john\n  Name: {main:user}-app\n  empty:\n\n[extra]\n[Main]\n  port: 80\n"))
+
+	c.Assert(err, check.IsNil)
+
+	var buf strings.Builder
+
+	n, err := cfg.WriteTo(&buf)
+
+	c.Assert(err, check.IsNil)
+	c.Assert(n, check.Equals, int64(buf.Len()))
+	c.Assert(buf.String(), check.Equals, "[main]\n  user: john\n  name: john-app\n  empty:\n  port: 80\n\n[extra]\n")
+
+	cfg2, err := Parse([]byte(buf.String()))
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg2.GetS("main:name"), check.Equals, "john-app")
+	c.Assert(cfg2.GetI("main:port"), check.Equals, 80)
+
+	var nilCfg *Config
+
+	_, err = nilCfg.WriteTo(&buf)
+	c.Assert(err, check.Equals, ErrNilConfig)
+}
+
+func (s *KNFSuite) TestWriterRoundTrip(c *check.C) {
+	os.Setenv("EK_KNF_TEST_RAW", "{main:user}-$${X}-${Y}-{{main:user}}-$")
+	defer os.Unsetenv("EK_KNF_TEST_RAW")
+
+	cfg, err := Parse([]byte(
+		"[main]\n  user: john\n  home: $${HOME}\n  prop: {{main:user}}\n" +
+			"  env: ${EK_KNF_TEST_RAW}\n  mixed: ${{main:user}} {main:user}\n",
+	))
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:home"), check.Equals, "${HOME}")
+	c.Assert(cfg.GetS("main:prop"), check.Equals, "{main:user}")
+	c.Assert(cfg.GetS("main:env"), check.Equals, "{main:user}-$${X}-${Y}-{{main:user}}-$")
+	c.Assert(cfg.GetS("main:mixed"), check.Equals, "${main:user} john")
+
+	var buf bytes.Buffer
+
+	_, err = cfg.WriteTo(&buf)
+	c.Assert(err, check.IsNil)
+
+	cfg2, err := Parse(buf.Bytes(), STRICT_ENV)
+
+	c.Assert(err, check.IsNil)
+
+	for _, prop := range cfg.Props("main") {
+		c.Assert(cfg2.GetS(Q("main", prop)), check.Equals, cfg.GetS(Q("main", prop)))
+	}
+}
+
+func (s *KNFSuite) TestEditor(c *check.C) {
+	data := `# Main config
+
+[main]
+    user: john
+    debug: true
+    # Application name
+    name:    {main:user}-app
+
+    # Empty property
+    empty:
+
+# Storage section
+[storage]
+    path: /var/lib/app
+
+# Log section
+[log]
+  level: info
+`
+
+	e := EditData([]byte(data))
+
+	c.Assert(e.Bytes(), check.DeepEquals, []byte(data))
+	c.Assert(e.Get("main:name"), check.Equals, "{main:user}-app")
+	c.Assert(e.Get("MAIN:USER"), check.Equals, "john")
+	c.Assert(e.Get("main:unknown"), check.Equals, "")
+	c.Assert(e.Get("unknown:name"), check.Equals, "")
+	c.Assert(e.Get("main"), check.Equals, "")
+	c.Assert(e.Has("main:empty"), check.Equals, true)
+	c.Assert(e.Has("main:unknown"), check.Equals, false)
+	c.Assert(e.HasSection("Storage"), check.Equals, true)
+	c.Assert(e.HasSection("unknown"), check.Equals, false)
+
+	c.Assert(e.Set("main:name", "{main:user}-service"), check.IsNil)
+	c.Assert(e.Set("main:empty", "test"), check.IsNil)
+	c.Assert(e.Set("main:port", "8080"), check.IsNil)
+	c.Assert(e.Set("log:level", ""), check.IsNil)
+	c.Assert(e.Set("http:port", "80"), check.IsNil)
+	c.Assert(e.Delete("main:debug"), check.Equals, true)
+	c.Assert(e.Delete("main:debug"), check.Equals, false)
+	c.Assert(e.DeleteSection("storage"), check.Equals, true)
+	c.Assert(e.DeleteSection("storage"), check.Equals, false)
+	c.Assert(e.AddSection("extra"), check.IsNil)
+
+	c.Assert(string(e.Bytes()), check.Equals, `# Main config
+
+[main]
+    user: john
+    # Application name
+    name:    {main:user}-service
+
+    # Empty property
+    empty: test
+    port: 8080
+
+# Log section
+[log]
+  level:
+
+[http]
+    port: 80
+
+[extra]
+`)
+
+	cfg, err := e.Config()
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:name"), check.Equals, "john-service")
+	c.Assert(cfg.GetI("http:port"), check.Equals, 80)
+
+	c.Assert(e.DeleteSection("extra"), check.Equals, true)
+	c.Assert(e.DeleteSection("http"), check.Equals, true)
+	c.Assert(e.DeleteSection("main"), check.Equals, true)
+	c.Assert(e.Set("log:level", "debug"), check.IsNil)
+	c.Assert(string(e.Bytes()), check.Equals, "# Main config\n\n# Log section\n[log]\n  level: debug\n")
+
+	c.Assert(e.Set("log", "1"), check.ErrorMatches, `invalid property name "log"`)
+	c.Assert(e.Set("log:level", "1\n2"), check.ErrorMatches, `property "log:level" value contains line break`)
+	c.Assert(e.Set("l]og:level", "1"), check.ErrorMatches, `invalid section name "l]og"`)
+	c.Assert(e.AddSection("log"), check.ErrorMatches, `section "log" already exists`)
+	c.Assert(e.AddSection(""), check.ErrorMatches, `invalid section name ""`)
+
+	e = EditData(nil)
+
+	c.Assert(e.Bytes(), check.IsNil)
+	c.Assert(e.Set("main:test", "1"), check.IsNil)
+	c.Assert(string(e.Bytes()), check.Equals, "[main]\n  test: 1\n")
+
+	e = EditData([]byte("[main]\n  a: 1\n  # Comment\n  # for b\n  b: 2\n"))
+
+	c.Assert(e.Delete("main:b"), check.Equals, true)
+	c.Assert(string(e.Bytes()), check.Equals, "[main]\n  a: 1\n")
+
+	var nilEditor *Editor
+
+	c.Assert(nilEditor.Get("main:test"), check.Equals, "")
+	c.Assert(nilEditor.Has("main:test"), check.Equals, false)
+	c.Assert(nilEditor.HasSection("main"), check.Equals, false)
+	c.Assert(nilEditor.Set("main:test", "1"), check.Equals, ErrNilEditor)
+	c.Assert(nilEditor.Delete("main:test"), check.Equals, false)
+	c.Assert(nilEditor.AddSection("main"), check.Equals, ErrNilEditor)
+	c.Assert(nilEditor.DeleteSection("main"), check.Equals, false)
+	c.Assert(nilEditor.Bytes(), check.IsNil)
+	c.Assert(nilEditor.Save(), check.Equals, ErrNilEditor)
+	c.Assert(nilEditor.SaveAs(""), check.Equals, ErrNilEditor)
+
+	_, err = nilEditor.Config()
+	c.Assert(err, check.Equals, ErrNilEditor)
+
+	_, err = nilEditor.WriteTo(os.Stdout)
+	c.Assert(err, check.Equals, ErrNilEditor)
+}
+
+func (s *KNFSuite) TestEditorCRLF(c *check.C) {
+	e := EditData([]byte("[main]\r\n  name: test\r\n"))
+
+	c.Assert(e.HasSection("main"), check.Equals, true)
+	c.Assert(e.Has("main:name"), check.Equals, true)
+	c.Assert(e.Get("main:name"), check.Equals, "test")
+	c.Assert(e.Set("main:name", "prod"), check.IsNil)
+	c.Assert(e.Set("main:port", "80"), check.IsNil)
+	c.Assert(string(e.Bytes()), check.Equals, "[main]\r\n  name: prod\r\n  port: 80\r\n")
+
+	cfg, err := e.Config()
+
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.GetS("main:name"), check.Equals, "prod")
+	c.Assert(cfg.GetI("main:port"), check.Equals, 80)
+
+	e = EditData([]byte("[main]\n  name: test\n"))
+	c.Assert(e.Set("main:name", "prod"), check.IsNil)
+	c.Assert(string(e.Bytes()), check.Equals, "[main]\n  name: prod\n")
+}
+
+func (s *KNFSuite) TestEditorSave(c *check.C) {
+	dir := c.MkDir()
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  # Port\n  port: 80\n")
+	c.Assert(os.Chmod(dir+"/app.knf", 0640), check.IsNil)
+
+	e, err := Edit(dir + "/app.knf")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(e.Set("main:port", "8080"), check.IsNil)
+	c.Assert(e.Save(), check.IsNil)
+
+	data, err := os.ReadFile(dir + "/app.knf")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data), check.Equals, "[main]\n  # Port\n  port: 8080\n")
+
+	info, err := os.Stat(dir + "/app.knf")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(info.Mode().Perm(), check.Equals, os.FileMode(0640))
+
+	c.Assert(e.SaveAs(dir+"/new.knf"), check.IsNil)
+
+	info, err = os.Stat(dir + "/new.knf")
+
+	c.Assert(err, check.IsNil)
+	c.Assert(info.Mode().Perm(), check.Equals, os.FileMode(0644))
+
+	files, err := os.ReadDir(dir)
+
+	c.Assert(err, check.IsNil)
+	c.Assert(files, check.HasLen, 2)
+
+	c.Assert(e.SaveAs(dir+"/unknown/app.knf"), check.ErrorMatches, `can't create temporary file: .*`)
+	c.Assert(EditData(nil).Save(), check.Equals, ErrCantSave)
+
+	_, err = Edit(dir + "/unknown.knf")
+	c.Assert(err, check.NotNil)
+}
+
+func (s *KNFSuite) TestWatcher(c *check.C) {
+	dir := c.MkDir()
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  name: app\n  port: 80\n\n@include inc.knf\n")
+	writeTestFile(c, dir+"/inc.knf", "[log]\n  level: info\n")
+
+	cfg, err := ReadWithDropIns(dir+"/app.knf", "")
+	c.Assert(err, check.IsNil)
+
+	eventChan := make(chan *ReloadEvent, 10)
+
+	w, err := cfg.Watch(WatchOptions{
+		Handler: func(event *ReloadEvent) { eventChan <- event },
+		Validators: Validators{
+			{"main:port", testValidatorSet, nil},
+		},
+	})
+
+	c.Assert(err, check.IsNil)
+	c.Assert(w.IsRunning(), check.Equals, true)
+
+	// Atomic replace
+	e, err := Edit(dir + "/app.knf")
+	c.Assert(err, check.IsNil)
+	c.Assert(e.Set("main:port", "8080"), check.IsNil)
+	c.Assert(e.Save(), check.IsNil)
+
+	event := waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.IsNil)
+	c.Assert(event.Changes, check.DeepEquals, []string{"main:port"})
+	c.Assert(cfg.GetI("main:port"), check.Equals, 8080)
+
+	// Included file
+	writeTestFile(c, dir+"/inc.knf", "[log]\n  level: debug\n")
+
+	event = waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.IsNil)
+	c.Assert(event.Changes, check.DeepEquals, []string{"log:level"})
+
+	// Drop-in file
+	c.Assert(os.MkdirAll(dir+"/app.d", 0755), check.IsNil)
+	time.Sleep(time.Second)
+	writeTestFile(c, dir+"/app.d/10-user.knf", "[main]\n  user: nobody\n")
+
+	event = waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.IsNil)
+	c.Assert(event.Changes, check.DeepEquals, []string{"main:user"})
+	c.Assert(cfg.GetS("main:user"), check.Equals, "nobody")
+
+	// Validation error
+	writeTestFile(c, dir+"/app.d/20-port.knf", "[main]\n  port:\n")
+
+	event = waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.ErrorMatches, `property main:port must be set`)
+	c.Assert(event.Changes, check.IsNil)
+	c.Assert(cfg.GetI("main:port"), check.Equals, 8080)
+
+	// Parsing error
+	writeTestFile(c, dir+"/app.d/20-port.knf", "port: 1\n")
+
+	event = waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.ErrorMatches, `can't read drop-in file .*`)
+	c.Assert(cfg.GetI("main:port"), check.Equals, 8080)
+
+	c.Assert(w.Reload(), check.NotNil)
+
+	c.Assert(w.Stop(), check.IsNil)
+	c.Assert(w.Stop(), check.Equals, ErrWatcherStopped)
+	c.Assert(w.IsRunning(), check.Equals, false)
+}
+
+func (s *KNFSuite) TestWatcherPolling(c *check.C) {
+	dir := c.MkDir()
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  port: 80\n")
+
+	err := Global(dir + "/app.knf")
+	c.Assert(err, check.IsNil)
+
+	dispatcher := events.NewDispatcher()
+	eventChan := make(chan *ReloadEvent, 10)
+
+	dispatcher.AddHandler(EV_RELOAD, func(payload any) {
+		eventChan <- payload.(*ReloadEvent)
+	})
+
+	dispatcher.AddHandler(EV_RELOAD_FAIL, func(payload any) {
+		eventChan <- payload.(*ReloadEvent)
+	})
+
+	w, err := Watch(WatchOptions{
+		Dispatcher: dispatcher,
+		Polling:    true,
+		Interval:   10 * time.Millisecond,
+		Signal:     true,
+	})
+
+	c.Assert(err, check.IsNil)
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  port: 8080\n  user: nobody\n")
+
+	event := waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.IsNil)
+	c.Assert(event.Changes, check.DeepEquals, []string{"main:port", "main:user"})
+	c.Assert(GetI("main:port"), check.Equals, 8080)
+
+	writeTestFile(c, dir+"/app.knf", "port: 1\n")
+
+	event = waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.NotNil)
+	c.Assert(GetI("main:port"), check.Equals, 8080)
+
+	c.Assert(w.Stop(), check.IsNil)
+
+	// Reload on SIGHUP
+	w, err = Watch(WatchOptions{
+		Dispatcher: dispatcher,
+		Polling:    true,
+		Interval:   time.Hour,
+		Signal:     true,
+	})
+
+	c.Assert(err, check.IsNil)
+
+	writeTestFile(c, dir+"/app.knf", "[main]\n  port: 9000\n  user: nobody\n")
+
+	proc, _ := os.FindProcess(os.Getpid())
+	c.Assert(proc.Signal(syscall.SIGHUP), check.IsNil)
+
+	event = waitReloadEvent(c, eventChan)
+
+	c.Assert(event.Err, check.IsNil)
+	c.Assert(event.Changes, check.DeepEquals, []string{"main:port"})
+
+	c.Assert(w.Stop(), check.IsNil)
+}
+
+func (s *KNFSuite) TestWatcherErrors(c *check.C) {
+	var nilCfg *Config
+	var nilWatcher *Watcher
+
+	_, err := nilCfg.Watch(WatchOptions{})
+	c.Assert(err, check.Equals, ErrNilConfig)
+
+	cfg, _ := Parse([]byte("[main]\n  port: 80\n"))
+
+	_, err = cfg.Watch(WatchOptions{})
+	c.Assert(err, check.Equals, ErrCantReload)
+
+	global.Store(nil)
+
+	_, err = Watch(WatchOptions{})
+	c.Assert(err, check.Equals, ErrNilConfig)
+
+	c.Assert(nilWatcher.Reload(), check.Equals, ErrNilWatcher)
+	c.Assert(nilWatcher.Stop(), check.Equals, ErrNilWatcher)
+	c.Assert(nilWatcher.IsRunning(), check.Equals, false)
+}
+
 func (s *KNFSuite) TestHelpers(c *check.C) {
 	c.Assert(Q("section", "prop"), check.Equals, "section:prop")
 }
@@ -759,3 +1622,56 @@ func (s *KNFSuite) BenchmarkBasic(c *check.C) {
 		GetS("string:test1")
 	}
 }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func writeTestFile(c *check.C, file, data string) {
+	err := os.WriteFile(file, []byte(data), 0644)
+
+	if err != nil {
+		c.Fatal(err.Error())
+	}
+}
+
+func waitReloadEvent(c *check.C, eventChan chan *ReloadEvent) *ReloadEvent {
+	select {
+	case event := <-eventChan:
+		return event
+	case <-time.After(5 * time.Second):
+		c.Fatal("reload event wasn't received")
+	}
+
+	return nil
+}
+
+func testValidatorSet(config IConfig, prop string, value any) error {
+	if config.GetS(prop) == "" {
+		return fmt.Errorf("property %s must be set", prop)
+	}
+
+	return nil
+}
+
+func testValidatorHasPrefix(config IConfig, prop string, value any) error {
+	if !strings.HasPrefix(config.GetS(prop), value.(string)) {
+		return fmt.Errorf("property %s must have prefix %q", prop, value)
+	}
+
+	return nil
+}
+
+func testValidatorGreater(config IConfig, prop string, value any) error {
+	if config.GetI(prop) <= value.(int) {
+		return fmt.Errorf("property %s must be greater than %d", prop, value)
+	}
+
+	return nil
+}
+
+func testValidatorLess(config IConfig, prop string, value any) error {
+	if config.GetI(prop) >= value.(int) {
+		return fmt.Errorf("property %s must be less than %d", prop, value)
+	}
+
+	return nil
+}
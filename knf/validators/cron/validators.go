@@ -23,6 +23,11 @@ var (
 	Expression = validateCronExpression
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"cron.Expression": {Func: Expression},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validateCronExpression checks if the given property contains a valid cron expression
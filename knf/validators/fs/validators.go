@@ -11,6 +11,7 @@ package fs
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/essentialkaos/ek/v14/fsutil"
 	"github.com/essentialkaos/ek/v14/knf"
@@ -42,6 +43,15 @@ var (
 	MatchPattern = validateMatchPattern
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"fs.Perms":        {Func: Perms},
+	"fs.Owner":        {Func: Owner},
+	"fs.OwnerGroup":   {Func: OwnerGroup},
+	"fs.FileMode":     {Func: FileMode, Parse: parseModeArg},
+	"fs.MatchPattern": {Func: MatchPattern},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validatePerms checks if configuration property contains path to object with given
@@ -254,6 +264,17 @@ func validateMatchPattern(config knf.IConfig, prop string, value any) error {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// parseModeArg parses file mode from tag argument
+func parseModeArg(arg string) (any, error) {
+	mode, err := strconv.ParseUint(arg, 8, 32)
+
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid file mode", arg)
+	}
+
+	return os.FileMode(mode), nil
+}
+
 // getValidatorInputError returns error for unsupported input type
 func getValidatorInputError(validator, prop string, value any) error {
 	return fmt.Errorf(
@@ -248,6 +248,28 @@ func (s *ValidatorSuite) TestMatchPattern(c *C) {
 	c.Assert(errs[3].Error(), Equals, "validator fs.MatchPattern doesn't support input with type <int> for checking test:test1 property")
 }
 
+func (s *ValidatorSuite) TestTags(c *C) {
+	type Config struct {
+		File string `knf:"test:test1" validate:"fs.Perms(FR),fs.FileMode(0644),fs.MatchPattern(/etc/*)"`
+	}
+
+	type BadConfig struct {
+		File string `knf:"test:test1" validate:"fs.FileMode(ABC)"`
+	}
+
+	configFile := createConfig(c, "/etc/passwd")
+
+	err := knf.Global(configFile)
+	c.Assert(err, IsNil)
+
+	c.Assert(knf.Decode(&Config{}, Tags), HasLen, 0)
+
+	errs := knf.Decode(&BadConfig{}, Tags)
+
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Error(), Equals, `field File: invalid argument for validator "fs.FileMode": "ABC" is not a valid file mode`)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 func createConfig(c *C, data string) string {
@@ -44,6 +44,17 @@ var (
 	HasIP = validateHasIP
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"network.IP":    {Func: IP},
+	"network.Port":  {Func: Port},
+	"network.MAC":   {Func: MAC},
+	"network.CIDR":  {Func: CIDR},
+	"network.URL":   {Func: URL},
+	"network.Mail":  {Func: Mail},
+	"network.HasIP": {Func: HasIP},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validateIP returns error if configuration property isn't a valid IP address
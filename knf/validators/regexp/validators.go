@@ -22,6 +22,11 @@ var (
 	Regexp = validateRegexp
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"regexp.Regexp": {Func: Regexp},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validateRegexp checks if the value of the property matches the given regexp pattern
@@ -28,6 +28,12 @@ var (
 	Group = validateGroup
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"system.User":  {Func: User},
+	"system.Group": {Func: Group},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validateUser checks if user exists on the system
@@ -30,6 +30,12 @@ var (
 	Timezone = validateTimezone
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"time.Format":   {Func: Format},
+	"time.Timezone": {Func: Timezone},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validateFormat checks if the given property contains a valid time conversion format
@@ -91,6 +91,31 @@ var (
 	TypeDur = validatorTypeDur
 )
 
+// Tags contains validators which can be used in struct tags with [knf.Config.Decode]
+var Tags = knf.TagValidators{
+	"Set":                {Func: Set},
+	"SetToAny":           {Func: SetToAny, Parse: parseListArg},
+	"SetToAnyIgnoreCase": {Func: SetToAnyIgnoreCase, Parse: parseListArg},
+	"Less":               {Func: Less, Parse: parseNumArg},
+	"Greater":            {Func: Greater, Parse: parseNumArg},
+	"InRange":            {Func: InRange, Parse: parseRangeArg},
+	"NotEquals":          {Func: NotEquals, Parse: parseAnyArg},
+	"LenShorter":         {Func: LenShorter, Parse: parseIntArg},
+	"LenLonger":          {Func: LenLonger, Parse: parseIntArg},
+	"LenEquals":          {Func: LenEquals, Parse: parseIntArg},
+	"HasPrefix":          {Func: HasPrefix},
+	"HasSuffix":          {Func: HasSuffix},
+	"SizeLess":           {Func: SizeLess},
+	"SizeGreater":        {Func: SizeGreater},
+	"DurShorter":         {Func: DurShorter, Parse: parseDurArg},
+	"DurLonger":          {Func: DurLonger, Parse: parseDurArg},
+	"TypeBool":           {Func: TypeBool},
+	"TypeNum":            {Func: TypeNum},
+	"TypeFloat":          {Func: TypeFloat},
+	"TypeSize":           {Func: TypeSize},
+	"TypeDur":            {Func: TypeDur},
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Range is numeric range
@@ -598,6 +623,94 @@ func isSliceContainsValue(s []string, value string, ignoreCase bool) bool {
 	return false
 }
 
+// parseListArg parses comma-separated list from tag argument
+func parseListArg(arg string) (any, error) {
+	if arg == "" {
+		return nil, fmt.Errorf("list is empty")
+	}
+
+	var result []string
+
+	for v := range strings.SplitSeq(arg, ",") {
+		result = append(result, strings.TrimSpace(v))
+	}
+
+	return result, nil
+}
+
+// parseNumArg parses integer or floating number from tag argument
+func parseNumArg(arg string) (any, error) {
+	i, err := strconv.Atoi(arg)
+
+	if err == nil {
+		return i, nil
+	}
+
+	f, err := strconv.ParseFloat(arg, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", arg)
+	}
+
+	return f, nil
+}
+
+// parseIntArg parses integer from tag argument
+func parseIntArg(arg string) (any, error) {
+	i, err := strconv.Atoi(arg)
+
+	if err != nil {
+		return nil, fmt.Errorf("%q is not an integer", arg)
+	}
+
+	return i, nil
+}
+
+// parseAnyArg parses number, boolean or string from tag argument
+func parseAnyArg(arg string) (any, error) {
+	v, err := parseNumArg(arg)
+
+	if err == nil {
+		return v, nil
+	}
+
+	b, err := strconv.ParseBool(arg)
+
+	if err == nil {
+		return b, nil
+	}
+
+	return arg, nil
+}
+
+// parseRangeArg parses range ("from,to") from tag argument
+func parseRangeArg(arg string) (any, error) {
+	from, to, ok := strings.Cut(arg, ",")
+
+	if !ok {
+		return nil, fmt.Errorf("range must contain two values")
+	}
+
+	fromVal, err := parseNumArg(strings.TrimSpace(from))
+
+	if err != nil {
+		return nil, err
+	}
+
+	toVal, err := parseNumArg(strings.TrimSpace(to))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Range{fromVal, toVal}, nil
+}
+
+// parseDurArg parses duration from tag argument
+func parseDurArg(arg string) (any, error) {
+	return time.ParseDuration(arg)
+}
+
 // getValidatorInputError returns error for validators that don't support given input type
 func getValidatorInputError(validator, prop string, value any) error {
 	return fmt.Errorf(
@@ -359,6 +359,54 @@ func (s *ValidatorSuite) TestTypeValidators(c *check.C) {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+func (s *ValidatorSuite) TestTags(c *check.C) {
+	type Config struct {
+		Name    string        `knf:"main:name" validate:"Set,SetToAny(test, prod),LenLonger(3),HasPrefix(te)"`
+		Port    int           `knf:"main:port" validate:"Greater(1024),Less(65535.5),InRange(1000, 2000),NotEquals(1500)"`
+		Debug   bool          `knf:"main:debug" validate:"TypeBool,NotEquals(true)"`
+		Mode    string        `knf:"main:mode" validate:"NotEquals(prod)"`
+		Size    uint64        `knf:"main:size,size" validate:"TypeSize,SizeLess(10mb)"`
+		Timeout time.Duration `knf:"main:timeout" validate:"DurShorter(1h),DurLonger(1m)"`
+	}
+
+	cfg, err := knf.Parse([]byte(`
+[main]
+  name: test
+  port: 1500
+  debug: false
+  mode: prod
+  size: 20mb
+  timeout: 30
+`))
+
+	c.Assert(err, check.IsNil)
+
+	var conf Config
+
+	errs := cfg.Decode(&conf, Tags)
+
+	c.Assert(errs, check.HasLen, 4)
+	c.Assert(errs[0], check.ErrorMatches, "property main:port can't be equal 1500")
+	c.Assert(errs[1], check.ErrorMatches, "property main:mode can't be equal \"prod\"")
+	c.Assert(errs[2], check.ErrorMatches, "property main:size can't be greater than 10485760 bytes")
+	c.Assert(errs[3], check.ErrorMatches, "property main:timeout can't be less than 1m0s")
+
+	type BadConfig struct {
+		Name string `knf:"main:name" validate:"SetToAny(),LenLonger(A),Less(A),InRange(1),InRange(A,1),InRange(1,A),DurLonger(A)"`
+	}
+
+	errs = cfg.Decode(&BadConfig{}, Tags)
+
+	c.Assert(errs, check.HasLen, 7)
+	c.Assert(errs[0], check.ErrorMatches, `field Name: invalid argument for validator "SetToAny": list is empty`)
+	c.Assert(errs[1], check.ErrorMatches, `field Name: invalid argument for validator "LenLonger": "A" is not an integer`)
+	c.Assert(errs[2], check.ErrorMatches, `field Name: invalid argument for validator "Less": "A" is not a number`)
+	c.Assert(errs[3], check.ErrorMatches, `field Name: invalid argument for validator "InRange": range must contain two values`)
+	c.Assert(errs[4], check.ErrorMatches, `field Name: invalid argument for validator "InRange": "A" is not a number`)
+	c.Assert(errs[5], check.ErrorMatches, `field Name: invalid argument for validator "InRange": "A" is not a number`)
+	c.Assert(errs[6], check.ErrorMatches, `field Name: invalid argument for validator "DurLonger": .*`)
+}
+
 func createConfig(c *check.C, data string) string {
 	configPath := c.MkDir() + "/config.knf"
 
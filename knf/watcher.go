@@ -0,0 +1,488 @@
+package knf
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/essentialkaos/ek/v14/errors"
+	"github.com/essentialkaos/ek/v14/events"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Watcher events
+const (
+	EV_RELOAD      = "knf.reload"      // Configuration successfully reloaded
+	EV_RELOAD_FAIL = "knf.reload.fail" // Configuration reload failed
+)
+
+// DEFAULT_WATCH_INTERVAL is default interval between checks of configuration
+// files if file system notifications are not available
+const DEFAULT_WATCH_INTERVAL = 5 * time.Second
+
+// _WATCH_DELAY is delay before reloading configuration after file system
+// notification, used for merging series of notifications into one reload
+const _WATCH_DELAY = 250 * time.Millisecond
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WatchOptions contains watcher options
+type WatchOptions struct {
+	// Validators are executed for new configuration before applying it. If
+	// validation fails, current configuration is kept.
+	Validators Validators
+
+	// Handler is a function called after every reload with changes
+	Handler ReloadHandler
+
+	// Dispatcher is used for sending reload events (EV_RELOAD, EV_RELOAD_FAIL)
+	Dispatcher *events.Dispatcher
+
+	// Interval is interval between checks of configuration files if file system
+	// notifications are not available or disabled
+	Interval time.Duration
+
+	// Polling is flag for using polling instead of file system notifications
+	Polling bool
+
+	// Signal is flag for reloading configuration on SIGHUP
+	Signal bool
+}
+
+// ReloadEvent is payload of watcher events
+type ReloadEvent struct {
+	Changes []string // Names of changed properties
+	Err     error    // Reload or validation error
+}
+
+// ReloadHandler is a function that handles configuration reload
+type ReloadHandler func(event *ReloadEvent)
+
+// Watcher watches configuration files and reloads configuration on change
+type Watcher struct {
+	getConfig func() *Config
+	options   WatchOptions
+	notifier  notifier
+	sigChan   chan os.Signal
+	files     map[string]fileState
+	dropIns   string
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mx        sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// notifier is source of file system notifications
+type notifier interface {
+	// watch adds given directories to the list of watched directories
+	watch(dirs []string) error
+
+	// events returns channel with paths to changed files
+	events() <-chan string
+
+	// close stops watching
+	close() error
+}
+
+// fileState contains info about file used for detecting changes
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrNilWatcher is returned if watcher is nil
+	ErrNilWatcher = errors.New("watcher is nil")
+
+	// ErrWatcherStopped is returned by Stop if watcher is already stopped
+	ErrWatcherStopped = errors.New("watcher is already stopped")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Watch starts watcher for global configuration
+func Watch(options WatchOptions) (*Watcher, error) {
+	cfg := global.Load()
+
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	return startWatcher(cfg, global.Load, options)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Watch starts watcher which reloads configuration if any of configuration files
+// (including included files and drop-ins) is changed. On Linux, inotify is used
+// for tracking changes, on other systems (or if polling is enabled in options)
+// files are checked with given interval.
+func (c *Config) Watch(options WatchOptions) (*Watcher, error) {
+	if c == nil {
+		return nil, ErrNilConfig
+	}
+
+	return startWatcher(c, func() *Config { return c }, options)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Reload reloads configuration immediately
+func (w *Watcher) Reload() error {
+	if w == nil || w.getConfig == nil {
+		return ErrNilWatcher
+	}
+
+	return w.reload()
+}
+
+// Stop stops watcher
+func (w *Watcher) Stop() error {
+	if w == nil || w.getConfig == nil {
+		return ErrNilWatcher
+	}
+
+	w.mx.Lock()
+
+	if w.cancel == nil {
+		w.mx.Unlock()
+		return ErrWatcherStopped
+	}
+
+	w.cancel()
+	w.cancel = nil
+
+	w.mx.Unlock()
+
+	w.wg.Wait()
+
+	if w.notifier != nil {
+		return w.notifier.close()
+	}
+
+	return nil
+}
+
+// IsRunning returns true if watcher is started
+func (w *Watcher) IsRunning() bool {
+	if w == nil || w.getConfig == nil {
+		return false
+	}
+
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	return w.cancel != nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startWatcher creates and starts new watcher
+func startWatcher(cfg *Config, getConfig func() *Config, options WatchOptions) (*Watcher, error) {
+	if cfg.file == "" && cfg.dropIns == "" {
+		return nil, ErrCantReload
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = DEFAULT_WATCH_INTERVAL
+	}
+
+	w := &Watcher{getConfig: getConfig, options: options}
+
+	if !options.Polling {
+		w.notifier, _ = newNotifier()
+	}
+
+	err := w.updateFiles(cfg)
+
+	if err != nil {
+		if w.notifier != nil {
+			w.notifier.close()
+		}
+
+		return nil, err
+	}
+
+	if options.Signal {
+		w.sigChan = make(chan os.Signal, 1)
+		signal.Notify(w.sigChan, syscall.SIGHUP)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w.cancel = cancel
+	w.wg.Add(1)
+
+	go w.loop(ctx)
+
+	return w, nil
+}
+
+// loop is watcher main loop
+func (w *Watcher) loop(ctx context.Context) {
+	defer w.wg.Done()
+
+	var notifyChan <-chan string
+	var pollChan <-chan time.Time
+
+	if w.notifier != nil {
+		notifyChan = w.notifier.events()
+	} else {
+		ticker := time.NewTicker(w.options.Interval)
+		defer ticker.Stop()
+		pollChan = ticker.C
+	}
+
+	if w.sigChan != nil {
+		defer signal.Stop(w.sigChan)
+	}
+
+	timer := time.NewTimer(_WATCH_DELAY)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-w.sigChan:
+			w.reload()
+
+		case file, ok := <-notifyChan:
+			if !ok {
+				notifyChan = nil
+				continue
+			}
+
+			if w.isWatched(file) {
+				timer.Reset(_WATCH_DELAY)
+			}
+
+		case <-timer.C:
+			w.reload()
+
+		case <-pollChan:
+			if w.isModified() {
+				w.reload()
+			}
+		}
+	}
+}
+
+// reload reloads configuration and notifies subscribers about changes
+func (w *Watcher) reload() error {
+	cfg := w.getConfig()
+
+	if cfg == nil {
+		return ErrNilConfig
+	}
+
+	changes, err := cfg.reloadAndValidate(w.options.Validators)
+
+	// Update file states even if reload failed, so the same changes don't
+	// trigger reload again
+	w.updateFiles(cfg)
+
+	if err == nil && len(changes) == 0 {
+		return nil
+	}
+
+	event := &ReloadEvent{Changes: changes, Err: err}
+
+	if w.options.Handler != nil {
+		w.options.Handler(event)
+	}
+
+	if w.options.Dispatcher != nil {
+		if err != nil {
+			w.options.Dispatcher.DispatchAndWait(EV_RELOAD_FAIL, event)
+		} else {
+			w.options.Dispatcher.DispatchAndWait(EV_RELOAD, event)
+		}
+	}
+
+	return err
+}
+
+// updateFiles updates list of watched files
+func (w *Watcher) updateFiles(cfg *Config) error {
+	cfg.mx.RLock()
+
+	dropIns := cfg.dropIns
+	files := make(map[string]fileState)
+
+	if cfg.file != "" {
+		files[getAbsPath(cfg.file)] = fileState{}
+	}
+
+	for _, s := range cfg.sources {
+		if s.File != "" {
+			files[getAbsPath(s.File)] = fileState{}
+		}
+	}
+
+	cfg.mx.RUnlock()
+
+	if dropIns != "" {
+		dropIns = getAbsPath(dropIns)
+		dropInFiles, _ := filepath.Glob(filepath.Join(dropIns, "*.knf"))
+
+		for _, file := range dropInFiles {
+			files[file] = fileState{}
+		}
+	}
+
+	for file := range files {
+		files[file] = getFileState(file)
+	}
+
+	w.mx.Lock()
+	w.files, w.dropIns = files, dropIns
+	w.mx.Unlock()
+
+	if w.notifier == nil {
+		return nil
+	}
+
+	var dirs []string
+
+	for file := range files {
+		dirs = append(dirs, filepath.Dir(file))
+	}
+
+	if dropIns != "" {
+		// Parent directory is watched for tracking creation of drop-ins directory
+		dirs = append(dirs, dropIns, filepath.Dir(dropIns))
+	}
+
+	slices.Sort(dirs)
+
+	return w.notifier.watch(slices.Compact(dirs))
+}
+
+// isWatched returns true if given file is one of configuration files
+func (w *Watcher) isWatched(file string) bool {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	_, ok := w.files[file]
+
+	if ok {
+		return true
+	}
+
+	if w.dropIns == "" {
+		return false
+	}
+
+	return file == w.dropIns ||
+		(filepath.Dir(file) == w.dropIns && filepath.Ext(file) == ".knf")
+}
+
+// isModified returns true if any of configuration files was modified, created
+// or removed
+func (w *Watcher) isModified() bool {
+	w.mx.Lock()
+
+	files := maps.Clone(w.files)
+	dropIns := w.dropIns
+
+	w.mx.Unlock()
+
+	if dropIns != "" {
+		dropInFiles, _ := filepath.Glob(filepath.Join(dropIns, "*.knf"))
+
+		for _, file := range dropInFiles {
+			_, ok := files[file]
+
+			if !ok {
+				return true
+			}
+		}
+	}
+
+	for file, state := range files {
+		if getFileState(file) != state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// reloadAndValidate reads configuration, validates it and replaces current data
+// with new data if there are no validation errors. It returns slice with names
+// of changed, added and removed properties.
+func (c *Config) reloadAndValidate(validators Validators) ([]string, error) {
+	nc, err := c.readSources()
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mx.RLock()
+	nc.aliases = c.aliases
+	c.mx.RUnlock()
+
+	if len(validators) != 0 {
+		errs := nc.Validate(validators)
+
+		if !errs.IsEmpty() {
+			return nil, errs
+		}
+	}
+
+	var changes []string
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for _, prop := range c.props {
+		if c.data[prop] != nc.data[prop] {
+			changes = append(changes, prop)
+		}
+	}
+
+	for _, prop := range nc.props {
+		if !slices.Contains(c.props, prop) && nc.data[prop] != "" {
+			changes = append(changes, prop)
+		}
+	}
+
+	c.update(nc)
+
+	return changes, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getFileState returns info about file
+func getFileState(file string) fileState {
+	info, err := os.Stat(file)
+
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{info.Size(), info.ModTime()}
+}
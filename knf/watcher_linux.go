@@ -0,0 +1,142 @@
+package knf
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _INOTIFY_MASK is mask with inotify events used for tracking changes
+const _INOTIFY_MASK = unix.IN_CLOSE_WRITE | unix.IN_CREATE | unix.IN_DELETE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_ONLYDIR
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// inotifyNotifier is inotify based notifier
+type inotifyNotifier struct {
+	fd   int
+	file *os.File
+	dirs map[int]string
+	ch   chan string
+	done chan struct{}
+	once sync.Once
+	mx   sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newNotifier creates new inotify notifier
+func newNotifier() (notifier, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n := &inotifyNotifier{
+		fd:   fd,
+		file: os.NewFile(uintptr(fd), "inotify"),
+		dirs: make(map[int]string),
+		ch:   make(chan string, 16),
+		done: make(chan struct{}),
+	}
+
+	go n.read()
+
+	return n, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// watch adds given directories to the list of watched directories. Missing
+// directories are ignored.
+func (n *inotifyNotifier) watch(dirs []string) error {
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	for _, dir := range dirs {
+		wd, err := unix.InotifyAddWatch(n.fd, dir, _INOTIFY_MASK)
+
+		switch {
+		case err == unix.ENOENT:
+			continue
+		case err != nil:
+			return err
+		}
+
+		n.dirs[wd] = dir
+	}
+
+	return nil
+}
+
+// events returns channel with paths to changed files
+func (n *inotifyNotifier) events() <-chan string {
+	return n.ch
+}
+
+// close stops watching
+func (n *inotifyNotifier) close() error {
+	var err error
+
+	n.once.Do(func() {
+		close(n.done)
+		err = n.file.Close()
+	})
+
+	return err
+}
+
+// read reads inotify events and sends paths to changed files to channel
+func (n *inotifyNotifier) read() {
+	defer close(n.ch)
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+
+	for {
+		size, err := n.file.Read(buf)
+
+		if err != nil {
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= size; {
+			ev := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			offset = nameStart + int(ev.Len)
+
+			if ev.Len == 0 || offset > size {
+				continue
+			}
+
+			n.mx.Lock()
+			dir := n.dirs[int(ev.Wd)]
+			n.mx.Unlock()
+
+			if dir == "" {
+				continue
+			}
+
+			name := strings.TrimRight(string(buf[nameStart:offset]), "\x00")
+
+			select {
+			case n.ch <- filepath.Join(dir, name):
+			case <-n.done:
+				return
+			}
+		}
+	}
+}
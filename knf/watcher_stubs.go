@@ -0,0 +1,28 @@
+//go:build !linux
+
+package knf
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"github.com/essentialkaos/ek/v14/errors"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// errNotifierNotSupported is returned if file system notifications are not
+// supported on current system
+var errNotifierNotSupported = errors.New("file system notifications are not supported")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newNotifier returns an error because file system notifications are not
+// supported, so changes are tracked using polling
+func newNotifier() (notifier, error) {
+	return nil, errNotifierNotSupported
+}
@@ -0,0 +1,580 @@
+package knf
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v14/errors"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _DEFAULT_INDENT is indentation used for new properties
+const _DEFAULT_INDENT = "  "
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Editor is format-preserving editor for configuration files. It modifies only
+// lines with changed properties and sections, so comments, ordering and whitespace
+// stay intact.
+type Editor struct {
+	lines []string
+	file  string
+	eol   string // Line ending (LF or CRLF)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilEditor = errors.New("editor is nil")
+	ErrCantSave  = errors.New("can't save configuration: path to file is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Edit reads configuration file and creates editor for it
+func Edit(file string) (*Editor, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+
+	if err != nil {
+		return nil, err
+	}
+
+	e := EditData(data)
+	e.file = file
+
+	return e, nil
+}
+
+// EditData creates editor for given configuration data. Both LF and CRLF line
+// endings are supported, original line ending is used for writing data back.
+func EditData(data []byte) *Editor {
+	e := &Editor{eol: "\n"}
+
+	if bytes.Contains(data, []byte("\r\n")) {
+		e.eol = "\r\n"
+	}
+
+	data = bytes.TrimSuffix(bytes.TrimSuffix(data, []byte("\n")), []byte("\r"))
+
+	if len(data) == 0 {
+		return e
+	}
+
+	e.lines = strings.Split(string(data), "\n")
+
+	for i, line := range e.lines {
+		e.lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return e
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WriteTo writes configuration data in KNF format to given writer. Values are
+// written with all macros already evaluated, text which looks like a macro is
+// escaped, so written data is parsed into the same values.
+func (c *Config) WriteTo(w io.Writer) (int64, error) {
+	if c == nil {
+		return 0, ErrNilConfig
+	}
+
+	var buf bytes.Buffer
+	var written []string
+
+	c.mx.RLock()
+
+	for _, section := range c.sections {
+		prefix := strings.ToLower(section) + _SYMBOL_DELIMITER
+
+		if slices.Contains(written, prefix) {
+			continue
+		}
+
+		if buf.Len() != 0 {
+			buf.WriteString("\n")
+		}
+
+		buf.WriteString(_SYMBOL_SECTION_START + section + _SYMBOL_SECTION_END + "\n")
+
+		for _, prop := range c.props {
+			if strings.HasPrefix(prop, prefix) {
+				buf.WriteString(formatProperty(
+					_DEFAULT_INDENT, prop[len(prefix):], escapeMacros(c.data[prop]),
+				) + "\n")
+			}
+		}
+
+		written = append(written, prefix)
+	}
+
+	c.mx.RUnlock()
+
+	return buf.WriteTo(w)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns raw (not evaluated) value of property
+func (e *Editor) Get(name string) string {
+	if e == nil {
+		return ""
+	}
+
+	index := e.findProperty(name)
+
+	if index == -1 {
+		return ""
+	}
+
+	_, value, _ := strings.Cut(e.lines[index], _SYMBOL_DELIMITER)
+
+	return strings.Trim(value, " \t")
+}
+
+// Has checks if property is defined
+func (e *Editor) Has(name string) bool {
+	return e != nil && e.findProperty(name) != -1
+}
+
+// HasSection checks if section is defined
+func (e *Editor) HasSection(section string) bool {
+	return e != nil && e.findSection(section) != -1
+}
+
+// Set sets property value. If property doesn't exist it will be added after the
+// last property in section. If section doesn't exist it will be added to the end
+// of configuration.
+func (e *Editor) Set(name, value string) error {
+	if e == nil {
+		return ErrNilEditor
+	}
+
+	if !isValidPropName(name) {
+		return fmt.Errorf("invalid property name %q", name)
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("property %q value contains line break", name)
+	}
+
+	section, prop, _ := strings.Cut(name, _SYMBOL_DELIMITER)
+	section, prop = strings.Trim(section, " \t"), strings.Trim(prop, " \t")
+
+	if !isValidSectionName(section) {
+		return fmt.Errorf("invalid section name %q", section)
+	}
+
+	index := e.findProperty(name)
+
+	if index != -1 {
+		e.lines[index] = updateProperty(e.lines[index], value)
+		return nil
+	}
+
+	start := e.findSection(section)
+
+	if start == -1 {
+		if len(e.lines) != 0 && strings.Trim(e.lines[len(e.lines)-1], " \t") != "" {
+			e.lines = append(e.lines, "")
+		}
+
+		e.lines = append(e.lines,
+			_SYMBOL_SECTION_START+section+_SYMBOL_SECTION_END,
+			formatProperty(e.getIndent(-1), prop, value),
+		)
+
+		return nil
+	}
+
+	last := e.getSectionLastProp(start)
+
+	e.lines = slices.Insert(
+		e.lines, last+1,
+		formatProperty(e.getIndent(last), prop, value),
+	)
+
+	return nil
+}
+
+// Delete deletes property along with comment lines right before it. It returns
+// true if property was deleted.
+func (e *Editor) Delete(name string) bool {
+	if e == nil {
+		return false
+	}
+
+	index := e.findProperty(name)
+
+	if index == -1 {
+		return false
+	}
+
+	start := index
+
+	for start > 0 && isComment(e.lines[start-1]) {
+		start--
+	}
+
+	e.lines = slices.Delete(e.lines, start, index+1)
+
+	return true
+}
+
+// AddSection adds empty section to the end of configuration
+func (e *Editor) AddSection(section string) error {
+	if e == nil {
+		return ErrNilEditor
+	}
+
+	if !isValidSectionName(section) {
+		return fmt.Errorf("invalid section name %q", section)
+	}
+
+	if e.findSection(section) != -1 {
+		return fmt.Errorf("section %q already exists", section)
+	}
+
+	if len(e.lines) != 0 && strings.Trim(e.lines[len(e.lines)-1], " \t") != "" {
+		e.lines = append(e.lines, "")
+	}
+
+	e.lines = append(e.lines, _SYMBOL_SECTION_START+section+_SYMBOL_SECTION_END)
+
+	return nil
+}
+
+// DeleteSection deletes section with all its properties and comments. Comment
+// lines right before section header are deleted as well. It returns true if
+// section was deleted.
+func (e *Editor) DeleteSection(section string) bool {
+	if e == nil {
+		return false
+	}
+
+	start := e.findSection(section)
+
+	if start == -1 {
+		return false
+	}
+
+	end := e.getSectionEnd(start)
+
+	// Comment lines and empty lines at the end of the section belong to the
+	// next section
+	for end < len(e.lines) && end > start+1 && isEmptyOrComment(e.lines[end-1]) {
+		end--
+	}
+
+	// Comment lines right before the header describe the section
+	for start > 0 && isComment(e.lines[start-1]) {
+		start--
+	}
+
+	// Remove empty lines left at the end of configuration
+	for end == len(e.lines) && start > 0 && strings.Trim(e.lines[start-1], " \t") == "" {
+		start--
+	}
+
+	// Remove duplicate empty line left after deleting
+	if end < len(e.lines) && strings.Trim(e.lines[end], " \t") == "" &&
+		(start == 0 || strings.Trim(e.lines[start-1], " \t") == "") {
+		end++
+	}
+
+	e.lines = slices.Delete(e.lines, start, end)
+
+	return true
+}
+
+// Config parses edited data and returns configuration
+func (e *Editor) Config() (*Config, error) {
+	if e == nil {
+		return nil, ErrNilEditor
+	}
+
+	return Parse(e.Bytes())
+}
+
+// Bytes returns edited configuration data
+func (e *Editor) Bytes() []byte {
+	if e == nil || len(e.lines) == 0 {
+		return nil
+	}
+
+	eol := e.eol
+
+	if eol == "" {
+		eol = "\n"
+	}
+
+	return []byte(strings.Join(e.lines, eol) + eol)
+}
+
+// WriteTo writes edited configuration data to given writer
+func (e *Editor) WriteTo(w io.Writer) (int64, error) {
+	if e == nil {
+		return 0, ErrNilEditor
+	}
+
+	n, err := w.Write(e.Bytes())
+
+	return int64(n), err
+}
+
+// Save atomically replaces configuration file which was used for creating editor
+// with edited data
+func (e *Editor) Save() error {
+	if e == nil {
+		return ErrNilEditor
+	}
+
+	if e.file == "" {
+		return ErrCantSave
+	}
+
+	return e.SaveAs(e.file)
+}
+
+// SaveAs atomically writes edited data to given file. Data is written to temporary
+// file which is renamed afterwards, so the file always contains complete
+// configuration. Permissions of existing file are preserved.
+func (e *Editor) SaveAs(file string) error {
+	if e == nil {
+		return ErrNilEditor
+	}
+
+	perms := os.FileMode(0644)
+	info, err := os.Stat(file)
+
+	if err == nil {
+		perms = info.Mode().Perm()
+	}
+
+	tmpFile := filepath.Join(
+		filepath.Dir(file),
+		fmt.Sprintf(".%s-%x", filepath.Base(file), rand.Uint64()),
+	)
+
+	fd, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms)
+
+	if err != nil {
+		return fmt.Errorf("can't create temporary file: %w", err)
+	}
+
+	_, err = e.WriteTo(fd)
+
+	if err == nil {
+		err = fd.Chmod(perms)
+	}
+
+	if err == nil {
+		err = fd.Sync()
+	}
+
+	closeErr := fd.Close()
+
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("can't close temporary file: %w", closeErr)
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile, file)
+	}
+
+	if err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findSection returns index of line with section header
+func (e *Editor) findSection(section string) int {
+	section = strings.ToLower(strings.Trim(section, " \t"))
+
+	for i, line := range e.lines {
+		name, ok := parseSectionHeader(line)
+
+		if ok && strings.ToLower(name) == section {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findProperty returns index of line with property
+func (e *Editor) findProperty(name string) int {
+	if !isValidPropName(name) {
+		return -1
+	}
+
+	section, prop, _ := strings.Cut(name, _SYMBOL_DELIMITER)
+	start := e.findSection(section)
+
+	if start == -1 {
+		return -1
+	}
+
+	prop = strings.ToLower(strings.Trim(prop, " \t"))
+
+	for i := start + 1; i < e.getSectionEnd(start); i++ {
+		if strings.ToLower(parsePropertyName(e.lines[i])) == prop {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// getSectionEnd returns index of the line after the last line of section
+func (e *Editor) getSectionEnd(start int) int {
+	for i := start + 1; i < len(e.lines); i++ {
+		if _, ok := parseSectionHeader(e.lines[i]); ok {
+			return i
+		}
+	}
+
+	return len(e.lines)
+}
+
+// getSectionLastProp returns index of the last property in section or index of
+// section header if section doesn't have properties
+func (e *Editor) getSectionLastProp(start int) int {
+	for i := e.getSectionEnd(start) - 1; i > start; i-- {
+		if parsePropertyName(e.lines[i]) != "" {
+			return i
+		}
+	}
+
+	return start
+}
+
+// getIndent returns indentation of property on the line with given index or
+// indentation of the first property in configuration
+func (e *Editor) getIndent(index int) string {
+	if index >= 0 && parsePropertyName(e.lines[index]) != "" {
+		return getLineIndent(e.lines[index])
+	}
+
+	for _, line := range e.lines {
+		if parsePropertyName(line) != "" {
+			return getLineIndent(line)
+		}
+	}
+
+	return _DEFAULT_INDENT
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseSectionHeader returns section name if line contains section header
+func parseSectionHeader(line string) (string, bool) {
+	line = strings.Trim(line, " \t")
+
+	if !strings.HasPrefix(line, _SYMBOL_SECTION_START) ||
+		!strings.HasSuffix(line, _SYMBOL_SECTION_END) {
+		return "", false
+	}
+
+	return line[1 : len(line)-1], true
+}
+
+// parsePropertyName returns property name if line contains property
+func parsePropertyName(line string) string {
+	line = strings.Trim(line, " \t")
+
+	if line == "" || strings.HasPrefix(line, _SYMBOL_COMMENT) || isIncludeDirective(line) {
+		return ""
+	}
+
+	name, _, ok := strings.Cut(line, _SYMBOL_DELIMITER)
+
+	if !ok {
+		return ""
+	}
+
+	return strings.Trim(name, " \t")
+}
+
+// updateProperty replaces property value keeping indentation and spacing
+// around the delimiter
+func updateProperty(line, value string) string {
+	index := strings.Index(line, _SYMBOL_DELIMITER) + 1
+	prefix, rest := line[:index], line[index:]
+	space := rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+
+	if space == "" {
+		space = " "
+	}
+
+	if value == "" {
+		return prefix
+	}
+
+	return prefix + space + value
+}
+
+// formatProperty formats line with property
+func formatProperty(indent, name, value string) string {
+	if value == "" {
+		return indent + name + _SYMBOL_DELIMITER
+	}
+
+	return indent + name + _SYMBOL_DELIMITER + " " + value
+}
+
+// escapeMacros escapes all text in value which can be parsed as a macro
+func escapeMacros(value string) string {
+	if !strings.ContainsAny(value, _SYMBOL_MACRO_START+_SYMBOL_MACRO_END) {
+		return value
+	}
+
+	return macroRE.ReplaceAllStringFunc(value, func(macro string) string {
+		if strings.HasPrefix(macro, "$") {
+			return "$" + macro
+		}
+
+		return _SYMBOL_MACRO_START + macro + _SYMBOL_MACRO_END
+	})
+}
+
+// getLineIndent returns leading whitespace of line
+func getLineIndent(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// isComment returns true if line contains comment
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.Trim(line, " \t"), _SYMBOL_COMMENT)
+}
+
+// isEmptyOrComment returns true if line is empty or contains comment
+func isEmptyOrComment(line string) bool {
+	line = strings.Trim(line, " \t")
+	return line == "" || strings.HasPrefix(line, _SYMBOL_COMMENT)
+}
+
+// isValidSectionName returns true if section name is valid
+func isValidSectionName(section string) bool {
+	return strings.Trim(section, " \t") != "" &&
+		!strings.ContainsAny(section, _SYMBOL_SECTION_START+_SYMBOL_SECTION_END+_SYMBOL_DELIMITER+"\r\n")
+}
@@ -0,0 +1,139 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ChildLogger is a logger that attaches persistent fields to every message
+type ChildLogger struct {
+	logger *Logger
+	fields []any
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// contextKey is type of the key used for storing logger in context
+type contextKey struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// With creates a child of the global logger with given persistent fields
+func With(fields ...Field) *ChildLogger {
+	return Global.With(fields...)
+}
+
+// NewContext returns a copy of the context that carries given logger
+func NewContext(ctx context.Context, logger ILogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns logger stored in the context or the global logger if the
+// context has no logger
+func FromContext(ctx context.Context) ILogger {
+	if ctx != nil {
+		logger, ok := ctx.Value(contextKey{}).(ILogger)
+
+		if ok && logger != nil {
+			return logger
+		}
+	}
+
+	return Global
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// With creates a child logger with given persistent fields
+func (l *Logger) With(fields ...Field) *ChildLogger {
+	return &ChildLogger{logger: l, fields: appendFields(nil, fields)}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// With creates a child logger with persistent fields of the current logger and
+// given fields
+func (l *ChildLogger) With(fields ...Field) *ChildLogger {
+	if l == nil {
+		return &ChildLogger{fields: appendFields(nil, fields)}
+	}
+
+	return &ChildLogger{
+		logger: l.logger,
+		fields: appendFields(append([]any(nil), l.fields...), fields),
+	}
+}
+
+// Print writes a formatted message at the specified level to the parent logger
+func (l *ChildLogger) Print(level uint8, f string, a ...any) error {
+	if l == nil || l.logger == nil {
+		return ErrNilLogger
+	}
+
+	if len(l.fields) == 0 {
+		return l.logger.Print(level, f, a...)
+	}
+
+	payload := make([]any, 0, len(a)+len(l.fields))
+	payload = append(payload, a...)
+	payload = append(payload, l.fields...)
+
+	return l.logger.Print(level, f, payload...)
+}
+
+// Debug writes a debug-level message to the parent logger
+func (l *ChildLogger) Debug(f string, a ...any) error {
+	return l.Print(DEBUG, f, a...)
+}
+
+// Info writes an info-level message to the parent logger
+func (l *ChildLogger) Info(f string, a ...any) error {
+	return l.Print(INFO, f, a...)
+}
+
+// Warn writes a warning-level message to the parent logger
+func (l *ChildLogger) Warn(f string, a ...any) error {
+	return l.Print(WARN, f, a...)
+}
+
+// Error writes an error-level message to the parent logger
+func (l *ChildLogger) Error(f string, a ...any) error {
+	return l.Print(ERROR, f, a...)
+}
+
+// Crit writes a critical-level message to the parent logger
+func (l *ChildLogger) Crit(f string, a ...any) error {
+	return l.Print(CRIT, f, a...)
+}
+
+// Aux writes an unskippable message (e.g. separator or header) to the parent logger
+func (l *ChildLogger) Aux(f string, a ...any) error {
+	return l.Print(AUX, f, a...)
+}
+
+// Is reports whether the given level meets or exceeds the parent logger's
+// minimum level
+func (l *ChildLogger) Is(level uint8) bool {
+	return l != nil && l.logger.Is(level)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// appendFields appends fields with non-empty keys to payload
+func appendFields(payload []any, fields []Field) []any {
+	for _, f := range fields {
+		if f.Key != "" {
+			payload = append(payload, f)
+		}
+	}
+
+	return payload
+}
@@ -8,8 +8,12 @@ package log
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"time"
+
+	"github.com/essentialkaos/ek/v14/errors"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -47,6 +51,9 @@ func Example() {
 	// Encode messages to JSON
 	logger.UseJSON = true
 
+	// Or use any other supported format (logfmt or OpenTelemetry-compatible JSON)
+	logger.Format = FORMAT_LOGFMT
+
 	// Print caller info
 	logger.WithCaller = true
 
@@ -74,6 +81,9 @@ func Example() {
 	// For log rotation we provide method Reopen
 	logger.Reopen()
 
+	// Or you can use built-in rotation by size and/or time period
+	logger.EnableRotation(Rotation{MaxSize: 100 * 1024 * 1024, Period: ROTATE_DAILY, MaxFiles: 7})
+
 	// If buffered IO is used, you should flush data before exit
 	logger.Flush()
 }
@@ -293,6 +303,128 @@ func ExamplePanicHandler() {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+func ExampleEnableRotation() {
+	err := Set("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Rotate log file every day or when it reaches 50MB, keep 14 compressed
+	// rotated files
+	err = EnableRotation(Rotation{
+		MaxSize:  50 * 1024 * 1024,
+		Period:   ROTATE_DAILY,
+		MaxFiles: 14,
+		Compress: true,
+	})
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	Info("Message will be written to rotated log file")
+}
+
+func ExampleRotate() {
+	err := Set("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Rotate log file right now
+	err = Rotate()
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
+
+func ExampleAddSink() {
+	err := Set("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	syslog, err := NewSyslogSink("myapp", SYSLOG_DAEMON)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Send only warnings and errors to syslog
+	AddSink(syslog, SinkConfig{MinLevel: WARN})
+
+	Info("Message will be written only to file")
+	Error("Message will be written to file and sent to syslog")
+
+	// Close all sinks before exit
+	ResetSinks()
+}
+
+func ExampleResetSinks() {
+	journal, err := NewJournalSink("myapp")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	AddSink(journal, SinkConfig{MinLevel: INFO})
+
+	Info("Message will be sent to systemd journal")
+
+	// Close and remove all sinks
+	ResetSinks()
+}
+
+func ExampleSetRateLimit() {
+	// Write no more than 10 similar error messages per second, every 100th
+	// suppressed message will be written anyway. Summary with number of
+	// suppressed messages will be written every 30 seconds.
+	SetRateLimit(ERROR, RateLimit{
+		Rate:     10,
+		Sample:   100,
+		Interval: 30 * time.Second,
+	})
+
+	for range 1000 {
+		Error("Can't connect to %s", "127.0.0.1:6379")
+	}
+}
+
+func ExampleWith() {
+	logger := With(F{"job", "cleanup"})
+
+	// All messages will contain field "job"
+	logger.Info("Job started")
+	logger.Info("Job finished", F{"duration", time.Minute})
+}
+
+func ExampleNewContext() {
+	logger := With(F{"request-id", "5bd1ee5b"})
+	ctx := NewContext(context.Background(), logger)
+
+	// Logger can be extracted from context in any function
+	FromContext(ctx).Info("Request processed")
+}
+
+func ExampleFromContext() {
+	ctx := context.Background()
+
+	// If context doesn't contain logger, the global logger is returned
+	FromContext(ctx).Info("Message will be written by the global logger")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 func ExampleLogger_Reopen() {
 	logger, err := New("/path/to/file.log", 0644)
 
@@ -305,6 +437,133 @@ func ExampleLogger_Reopen() {
 	logger.Reopen()
 }
 
+func ExampleLogger_EnableRotation() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Buffered IO is safe to use with built-in rotation
+	logger.EnableBufIO(time.Second)
+
+	// Rotate log file every hour and keep 24 rotated files
+	err = logger.EnableRotation(Rotation{Period: ROTATE_HOURLY, MaxFiles: 24})
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	logger.Info("Message will be written to rotated log file")
+	logger.Flush()
+}
+
+func ExampleLogger_Rotate() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Rotate log file right now
+	err = logger.Rotate()
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
+
+func ExampleLogger_AddSink() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	jsonSink, err := NewFileSink("/path/to/file.json", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	journal, err := NewJournalSink("myapp")
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Write all messages encoded to JSON to additional file
+	logger.AddSink(jsonSink, SinkConfig{MinLevel: DEBUG, UseJSON: true})
+
+	// Send errors to systemd journal
+	logger.AddSink(journal, SinkConfig{MinLevel: ERROR})
+
+	logger.Info("Message will be written to both files")
+	logger.Error("Message will be written to both files and sent to journal")
+
+	// Close all sinks before exit
+	logger.ResetSinks()
+}
+
+func ExampleLogger_ResetSinks() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	logger.AddSink(NewWriterSink(os.Stdout), SinkConfig{MinLevel: INFO})
+
+	logger.Info("Message will be written to file and stdout")
+
+	// Close and remove all sinks
+	logger.ResetSinks()
+}
+
+func ExampleLogger_SetRateLimit() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Allow bursts of 20 similar warnings and then 1 message per second
+	logger.SetRateLimit(WARN, RateLimit{Rate: 1, Burst: 20})
+
+	for range 100 {
+		logger.Warn("Request took too long")
+	}
+
+	// Disable rate limiting for warnings
+	logger.SetRateLimit(WARN, RateLimit{})
+}
+
+func ExampleLogger_With() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	reqLogger := logger.With(F{"request-id", "5bd1ee5b"}, F{"user", "bob"})
+
+	// Both messages will contain fields "request-id" and "user"
+	reqLogger.Info("Request received")
+	reqLogger.Info("Request processed", F{"status", 200})
+
+	// Child loggers can be extended with additional fields
+	reqLogger.With(F{"db", "main"}).Error("Can't execute query")
+}
+
 func ExampleLogger_MinLevel() {
 	logger, err := New("/path/to/file.log", 0644)
 
@@ -487,12 +746,38 @@ func ExampleLogger_PanicHandler() {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+func ExampleChildLogger_With() {
+	logger := With(F{"job", "cleanup"})
+
+	// Child logger will contain fields "job" and "step"
+	logger.With(F{"step", 1}).Info("Removing old files")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 func ExampleNewFields() {
 	f := NewFields(F{"user", "bob"}, F{"id", 200})
 
 	Debug("This is %d %s message", 2, "debug", f)
 }
 
+func ExampleErrorFields() {
+	logger, err := New("/path/to/file.log", 0644)
+
+	if err != nil {
+		panic(err.Error())
+	}
+
+	logger.UseJSON = true
+
+	err = errors.NewError("connection refused").
+		WithCode("E_DB_CONN").
+		WithAttr("host", "db1.example.com")
+
+	// Record will contain fields "error", "error.code", "error.host" and "error.stack"
+	logger.Error("Can't load user data", ErrorFields(err), F{"user", "bob"})
+}
+
 func ExampleFields_Add() {
 	// Fields do not require initialization
 	var f Fields
@@ -0,0 +1,213 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_TEXT   uint8 = 0 // FORMAT_TEXT is plain text format
+	FORMAT_JSON   uint8 = 1 // FORMAT_JSON is JSON format
+	FORMAT_LOGFMT uint8 = 2 // FORMAT_LOGFMT is logfmt (key=value) format
+	FORMAT_OTLP   uint8 = 3 // FORMAT_OTLP is JSON format compatible with OpenTelemetry log data model
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeLogfmt encodes message as logfmt and writes it into buffer. It returns
+// false if message is empty and must be skipped.
+func (l *Logger) encodeLogfmt(level uint8, msg string, a ...any) bool {
+	if level == AUX {
+		level = INFO
+	}
+
+	if msg == "" && len(a) == 0 {
+		return false
+	}
+
+	l.buf.WriteString("ts=")
+	l.buf.WriteString(formatLogfmtValue(l.formatDateTime(time.Now(), true)))
+	l.buf.WriteString(" level=")
+	l.buf.WriteString(getLevelName(level))
+
+	if l.WithCaller {
+		l.buf.WriteString(" caller=")
+		l.buf.WriteString(formatLogfmtValue(getCallerFromStack(l.WithFullCallerPath)))
+	}
+
+	operands, fields := splitPayload(a)
+
+	if msg != "" {
+		l.buf.WriteString(" msg=")
+
+		if len(operands) > 0 {
+			l.buf.WriteString(formatLogfmtValue(fmt.Sprintf(msg, operands...)))
+		} else {
+			l.buf.WriteString(formatLogfmtValue(msg))
+		}
+	}
+
+	if !l.DiscardFields {
+		for _, f := range fields {
+			t, ok := f.(Field)
+
+			if !ok {
+				continue
+			}
+
+			l.buf.WriteRune(' ')
+			l.buf.WriteString(formatLogfmtKey(t.Key))
+			l.buf.WriteRune('=')
+			l.buf.WriteString(formatLogfmtValue(l.formatFieldValue(t.Value)))
+		}
+	}
+
+	l.buf.WriteRune('\n')
+
+	return true
+}
+
+// encodeOTLP encodes message as JSON using OpenTelemetry log data model field names
+// and writes it into buffer. It returns false if message is empty and must be skipped.
+func (l *Logger) encodeOTLP(level uint8, msg string, a ...any) bool {
+	if level == AUX {
+		level = INFO
+	}
+
+	if msg == "" && len(a) == 0 {
+		return false
+	}
+
+	now := time.Now()
+
+	l.buf.WriteString(`{"timestamp":`)
+
+	if l.TimeLayout == "" {
+		l.buf.WriteString(strconv.FormatInt(now.UnixNano(), 10))
+	} else {
+		l.buf.WriteString(strconv.Quote(l.formatDateTime(now, true)))
+	}
+
+	l.buf.WriteString(`,"severity_text":"`)
+	l.buf.WriteString(strings.ToUpper(getLevelName(level)))
+	l.buf.WriteString(`","severity_number":`)
+	l.buf.WriteString(strconv.Itoa(getOTLPSeverity(level)))
+
+	operands, fields := splitPayload(a)
+
+	if len(operands) > 0 {
+		l.buf.WriteString(`,"body":` + strconv.Quote(fmt.Sprintf(msg, operands...)))
+	} else {
+		l.buf.WriteString(`,"body":` + strconv.Quote(msg))
+	}
+
+	if l.DiscardFields {
+		fields = nil
+	}
+
+	if l.WithCaller || len(fields) != 0 {
+		l.buf.WriteString(`,"attributes":{`)
+
+		if l.WithCaller {
+			file, line, _ := strings.Cut(getCallerFromStack(l.WithFullCallerPath), ":")
+			l.buf.WriteString(`"code.filepath":` + strconv.Quote(file))
+
+			if line != "" {
+				l.buf.WriteString(`,"code.lineno":` + line)
+			}
+
+			if len(fields) != 0 {
+				l.buf.WriteRune(',')
+			}
+		}
+
+		l.writeJSONFields(fields)
+		l.buf.WriteRune('}')
+	}
+
+	l.buf.WriteString("}\n")
+
+	return true
+}
+
+// formatFieldValue formats field value for logfmt
+func (l *Logger) formatFieldValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case time.Time:
+		return l.formatDateTime(t, true)
+	case time.Duration:
+		return t.String()
+	case fmt.Stringer:
+		return t.String()
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getLevelName returns name of level used in structured formats
+func getLevelName(level uint8) string {
+	switch level {
+	case DEBUG:
+		return "debug"
+	case WARN:
+		return "warn"
+	case ERROR:
+		return "error"
+	case CRIT:
+		return "fatal"
+	}
+
+	return "info"
+}
+
+// getOTLPSeverity returns OpenTelemetry severity number for given level
+func getOTLPSeverity(level uint8) int {
+	switch level {
+	case DEBUG:
+		return 5
+	case WARN:
+		return 13
+	case ERROR:
+		return 17
+	case CRIT:
+		return 21
+	}
+
+	return 9
+}
+
+// formatLogfmtKey removes all forbidden symbols from logfmt key
+func formatLogfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
+
+// formatLogfmtValue quotes logfmt value if required
+func formatLogfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\\") ||
+		strings.ContainsFunc(value, func(r rune) bool { return r < ' ' }) {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
@@ -11,7 +11,6 @@ package log
 import (
 	"bufio"
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -22,6 +21,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/essentialkaos/ek/v14/errors"
 	"github.com/essentialkaos/ek/v14/fmtc"
 	"github.com/essentialkaos/ek/v14/strutil"
 )
@@ -70,7 +70,8 @@ type Logger struct {
 
 	TimeLayout         string // Date and time layout used for rendering dates
 	UseColors          bool   // Enable ANSI escape codes for colors in output
-	UseJSON            bool   // Encode messages to JSON
+	UseJSON            bool   // Encode messages to JSON (same as FORMAT_JSON)
+	Format             uint8  // Output format (FORMAT_TEXT, FORMAT_JSON, FORMAT_LOGFMT, FORMAT_OTLP)
 	WithCaller         bool   // Show caller info
 	WithFullCallerPath bool   // Show full path of caller
 	DiscardFields      bool   // Don't write fields to log
@@ -84,6 +85,9 @@ type Logger struct {
 	perms         os.FileMode
 	useBufIO      bool
 	bufIOStopChan chan struct{}
+	rotator       *rotator
+	sinks         []*sinkEntry
+	limiters      map[uint8]*limiter
 }
 
 // F is an alias for [Field]
@@ -285,7 +289,7 @@ func PanicHandler(msg string) {
 		return
 	}
 
-	if Global.UseJSON {
+	if Global.isStructured() {
 		Global.Crit("%s: %v", msg, r, F{"panic-stack", string(debug.Stack())})
 	} else {
 		Global.Crit("%s: %v (%s)", msg, r, extractPanicPath(debug.Stack()))
@@ -395,6 +399,10 @@ func (l *Logger) Set(file string, perms os.FileMode) error {
 		l.w = bufio.NewWriter(l.fd)
 	}
 
+	if l.rotator != nil {
+		l.rotator.updateSize(l.fd)
+	}
+
 	return nil
 }
 
@@ -407,15 +415,15 @@ func (l *Logger) Print(level uint8, f string, a ...any) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.minLevel > level {
+	if !l.isOutputRequired(level) && !l.isSinkRequired(level) {
 		return nil
 	}
 
-	if l.UseJSON {
-		return l.writeJSON(level, f, a...)
+	if !l.isAllowedByLimiter(level, f) {
+		return nil
 	}
 
-	return l.writeText(level, f, a...)
+	return l.write(level, f, a...)
 }
 
 // Flush writes any pending buffered data to the underlying file
@@ -425,15 +433,21 @@ func (l *Logger) Flush() error {
 	}
 
 	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	if l.w == nil {
-		l.mu.Unlock()
-		return nil
+	var err error
+
+	if l.w != nil {
+		err = l.w.Flush()
 	}
 
-	err := l.w.Flush()
+	for _, s := range l.sinks {
+		sinkErr := s.sink.Flush()
 
-	l.mu.Unlock()
+		if err == nil {
+			err = sinkErr
+		}
+	}
 
 	return err
 }
@@ -498,7 +512,7 @@ func (l *Logger) Divider() error {
 		return ErrNilLogger
 	}
 
-	if l.UseJSON {
+	if l.isStructured() {
 		return nil
 	}
 
@@ -522,7 +536,7 @@ func (l *Logger) PanicHandler(msg string) {
 	if r != nil {
 		stack := debug.Stack()
 
-		if l.UseJSON {
+		if l.isStructured() {
 			l.Crit("%s: %v", msg, r, F{"panic-stack", string(stack)})
 		} else {
 			l.Crit("%s: %v (%s)", msg, r, extractPanicPath(stack))
@@ -588,6 +602,44 @@ func NewFields(fields ...Field) *Fields {
 	return result
 }
 
+// ErrorFields creates a new Fields collection with error message ("error"),
+// code ("error.code"), stack trace ("error.stack") and attributes ("error.<key>")
+// of given error. Code, stack trace and attributes are available only for
+// [errors.Error].
+func ErrorFields(err error) *Fields {
+	result := &Fields{}
+
+	if err == nil {
+		return result
+	}
+
+	result.AddF("error", err.Error())
+
+	code := errors.CodeOf(err)
+
+	if code != "" {
+		result.AddF("error.code", code)
+	}
+
+	for _, a := range errors.AttrsOf(err) {
+		result.AddF("error."+a.Key, a.Value)
+	}
+
+	stack := errors.StackOf(err)
+
+	if len(stack) != 0 {
+		frames := make([]string, 0, len(stack))
+
+		for _, f := range stack {
+			frames = append(frames, f.String())
+		}
+
+		result.AddF("error.stack", strings.Join(frames, "\n"))
+	}
+
+	return result
+}
+
 // Add appends one or more fields to the collection, skipping any with empty keys
 func (f *Fields) Add(fields ...Field) *Fields {
 	if f == nil || len(fields) == 0 {
@@ -628,28 +680,105 @@ func (f *Fields) Reset() *Fields {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// writeText writes text message into log
-func (l *Logger) writeText(level uint8, f string, a ...any) error {
-	var color string
+// write writes message to output and all sinks
+func (l *Logger) write(level uint8, f string, a ...any) error {
+	var err error
 
-	w := l.getWriter(level)
+	if l.isOutputRequired(level) {
+		err = l.rotateIfRequired()
 
-	if l.UseColors {
-		color = strutil.B(fmtc.IsTag(Colors[level]), Colors[level], "")
+		if err != nil {
+			return err
+		}
+
+		err = l.writeRecord(level, f, a...)
+	}
+
+	sinkErr := l.writeToSinks(level, f, a...)
+
+	if err != nil {
+		return err
 	}
 
+	return sinkErr
+}
+
+// writeRecord encodes message using logger format and writes it into log
+func (l *Logger) writeRecord(level uint8, f string, a ...any) error {
+	l.buf.Reset()
+
+	if !l.encode(l.getFormat(), level, l.UseColors, f, a...) {
+		return nil
+	}
+
+	n, err := l.buf.WriteTo(l.getWriter(level))
+
+	l.trackWritten(n)
+	l.buf.Reset()
+
+	return err
+}
+
+// writeToSinks writes message to all sinks with suitable minimum level
+func (l *Logger) writeToSinks(level uint8, f string, a ...any) error {
 	var err error
 
+	for _, s := range l.sinks {
+		if s.cfg.MinLevel > level {
+			continue
+		}
+
+		l.buf.Reset()
+
+		if !l.encode(s.cfg.getFormat(), level, false, f, a...) {
+			continue
+		}
+
+		sinkErr := s.sink.Write(level, l.buf.Bytes())
+
+		if err == nil {
+			err = sinkErr
+		}
+	}
+
 	l.buf.Reset()
 
-	if l.UseColors {
+	return err
+}
+
+// encode encodes message using given format and writes it into buffer. It returns
+// false if message must be skipped.
+func (l *Logger) encode(format, level uint8, useColors bool, f string, a ...any) bool {
+	switch format {
+	case FORMAT_JSON:
+		return l.encodeJSON(level, f, a...)
+	case FORMAT_LOGFMT:
+		return l.encodeLogfmt(level, f, a...)
+	case FORMAT_OTLP:
+		return l.encodeOTLP(level, f, a...)
+	}
+
+	l.encodeText(level, useColors, f, a...)
+
+	return true
+}
+
+// encodeText encodes message as text and writes it into buffer
+func (l *Logger) encodeText(level uint8, useColors bool, f string, a ...any) {
+	var color string
+
+	if useColors {
+		color = strutil.B(fmtc.IsTag(Colors[level]), Colors[level], "")
+	}
+
+	if useColors {
 		fmtc.Fprintf(&l.buf, "{s}[ %s ]{!} ", l.formatDateTime(time.Now(), false))
 	} else {
 		l.buf.WriteString("[ " + l.formatDateTime(time.Now(), false) + " ] ")
 	}
 
 	if l.WithCaller {
-		if l.UseColors {
+		if useColors {
 			fmtc.Fprintf(&l.buf, "{s-}(%s){!} ", getCallerFromStack(l.WithFullCallerPath))
 		} else {
 			l.buf.WriteString("(" + getCallerFromStack(l.WithFullCallerPath) + ") ")
@@ -657,7 +786,7 @@ func (l *Logger) writeText(level uint8, f string, a ...any) error {
 	}
 
 	if l.isPrefixRequired(level) {
-		if l.UseColors {
+		if useColors {
 			fmtc.Fprintf(&l.buf, color+"{@}%s{!} ", PrefixMap[level])
 		} else {
 			fmt.Fprint(&l.buf, PrefixMap[level]+" ")
@@ -666,7 +795,7 @@ func (l *Logger) writeText(level uint8, f string, a ...any) error {
 
 	operands, fields := splitPayload(a)
 
-	if l.UseColors {
+	if useColors {
 		fmtc.Fprintf(&l.buf, color+f+"{!}", operands...)
 	} else {
 		fmt.Fprintf(&l.buf, f, operands...)
@@ -674,7 +803,7 @@ func (l *Logger) writeText(level uint8, f string, a ...any) error {
 
 	if len(fields) > 0 && !l.DiscardFields {
 		l.buf.WriteRune(' ')
-		if l.UseColors {
+		if useColors {
 			fmtc.Fprint(&l.buf, strutil.B(level == DEBUG, Colors[DEBUG], "{b}")+fieldsToText(fields)+"{!}")
 		} else {
 			l.buf.WriteString(fieldsToText(fields))
@@ -684,26 +813,20 @@ func (l *Logger) writeText(level uint8, f string, a ...any) error {
 	if f == "" || strutil.Tail(f, 1) != "\n" {
 		l.buf.WriteRune('\n')
 	}
-
-	_, err = l.buf.WriteTo(w)
-
-	l.buf.Reset()
-
-	return err
 }
 
-// writeJSON writes JSON encoded message into log
-func (l *Logger) writeJSON(level uint8, msg string, a ...any) error {
+// encodeJSON encodes message as JSON and writes it into buffer. It returns false
+// if message is empty and must be skipped.
+func (l *Logger) encodeJSON(level uint8, msg string, a ...any) bool {
 	// Aux in JSON is info
 	if level == AUX {
 		level = INFO
 	}
 
 	if msg == "" && len(a) == 0 {
-		return nil
+		return false
 	}
 
-	l.buf.Reset()
 	l.buf.WriteRune('{')
 
 	l.writeJSONLevel(level)
@@ -733,11 +856,7 @@ func (l *Logger) writeJSON(level uint8, msg string, a ...any) error {
 	l.buf.WriteRune('}')
 	l.buf.WriteRune('\n')
 
-	_, err := l.buf.WriteTo(l.getWriter(level))
-
-	l.buf.Reset()
-
-	return err
+	return true
 }
 
 // getWriter returns writer based on logger configuration
@@ -750,13 +869,20 @@ func (l *Logger) getWriter(level uint8) io.Writer {
 		return l.fd
 	}
 
-	if l.UseJSON || (level != ERROR && level != CRIT) {
+	if l.isStructured() || (level != ERROR && level != CRIT) {
 		return os.Stdout
 	}
 
 	return os.Stderr
 }
 
+// trackWritten updates size of current log file used for rotation
+func (l *Logger) trackWritten(n int64) {
+	if l.rotator != nil && l.fd != nil {
+		l.rotator.size += n
+	}
+}
+
 // formatDateTime applies logger datetime layout for given date
 func (l *Logger) formatDateTime(t time.Time, isJSON bool) string {
 	switch {
@@ -769,6 +895,26 @@ func (l *Logger) formatDateTime(t time.Time, isJSON bool) string {
 	return t.Format(l.TimeLayout)
 }
 
+// getFormat returns output format of the logger
+func (l *Logger) getFormat() uint8 {
+	if l.Format == FORMAT_TEXT && l.UseJSON {
+		return FORMAT_JSON
+	}
+
+	return l.Format
+}
+
+// isStructured returns true if logger uses structured output format
+func (l *Logger) isStructured() bool {
+	return l.getFormat() != FORMAT_TEXT
+}
+
+// isOutputRequired returns true if message with given level must be written
+// to the main output
+func (l *Logger) isOutputRequired(level uint8) bool {
+	return l.minLevel <= level && (l.fd != nil || len(l.sinks) == 0)
+}
+
 // isPrefixRequired returns true if prefix must be shown
 func (l *Logger) isPrefixRequired(level uint8) bool {
 	switch {
@@ -1029,7 +1175,7 @@ func getCallerFromStack(full bool) string {
 	pcs := make([]uintptr, 64)
 	n := runtime.Callers(2, pcs)
 
-	file := ""
+	pkg := ""
 	frames := runtime.CallersFrames(pcs[:n])
 
 	for {
@@ -1039,11 +1185,12 @@ func getCallerFromStack(full bool) string {
 			break
 		}
 
-		if file == "" {
-			file = frame.File
+		if pkg == "" {
+			pkg = extractPackageFromFrame(frame)
 		}
 
-		if file == frame.File {
+		// Skip all frames from this package except tests
+		if pkg == extractPackageFromFrame(frame) && !strings.HasSuffix(frame.File, "_test.go") {
 			continue
 		}
 
@@ -1063,6 +1210,14 @@ func extractCallerFromFrame(f runtime.Frame, full bool) string {
 	return f.File[index+1:] + ":" + strconv.Itoa(f.Line)
 }
 
+// extractPackageFromFrame extracts package path from frame function name
+func extractPackageFromFrame(f runtime.Frame) string {
+	index := strings.LastIndexByte(f.Function, '/')
+	pkg, _, _ := strings.Cut(f.Function[index+1:], ".")
+
+	return f.Function[:index+1] + pkg
+}
+
 // extractPanicPath tries to extract path to the line with panic
 func extractPanicPath(stackData []byte) string {
 	stack := string(stackData)
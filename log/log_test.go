@@ -8,7 +8,10 @@ package log
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"net"
 	"os"
 	"runtime"
 	"strings"
@@ -16,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/essentialkaos/ek/v14/errors"
 	"github.com/essentialkaos/ek/v14/fmtc"
 	"github.com/essentialkaos/ek/v14/fsutil"
 
@@ -498,14 +502,137 @@ func (ls *LogSuite) TestWithCaller(c *C) {
 
 	c.Assert(len(dataSlice), Equals, 3)
 
-	c.Assert(dataSlice[0][28:], Equals, "(log/log_test.go:483) Test info 1")
-	c.Assert(dataSlice[1][28:], Equals, "(log/log_test.go:488) Test info 2")
+	c.Assert(dataSlice[0][28:], Equals, "(log/log_test.go:487) Test info 1")
+	c.Assert(dataSlice[1][28:], Equals, "(log/log_test.go:492) Test info 2")
 
 	frm := runtime.Frame{File: "/path/to/my/app/code/test.go", Line: 10}
 	c.Assert(extractCallerFromFrame(frm, true), Equals, "/path/to/my/app/code/test.go:10")
 	c.Assert(extractCallerFromFrame(frm, false), Equals, "code/test.go:10")
 }
 
+func (ls *LogSuite) TestLogfmt(c *C) {
+	logfile := ls.TempDir + "/file.logfmt"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	l.Format = FORMAT_LOGFMT
+	l.WithCaller = true
+	l.TimeLayout = "2006-01-02"
+
+	c.Assert(l.Divider(), IsNil)
+	c.Assert(l.Aux(""), IsNil)
+	c.Assert(l.Info("Test info %d", 1, F{"id", 1}, F{"user name", "John Doe"}), IsNil)
+	c.Assert(l.Warn("Test", F{"empty", ""}, F{"dur", time.Second}, F{"quote", `a"b`}), IsNil)
+	c.Assert(l.Error("Test\terror"), IsNil)
+	c.Assert(l.Crit("", F{"ts", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}), IsNil)
+
+	l.WithCaller = false
+	l.DiscardFields = true
+
+	c.Assert(l.Print(AUX, "Test aux", F{"id", 1}), IsNil)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+
+	ts := time.Now().Format("2006-01-02")
+	dataSlice := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	c.Assert(dataSlice, HasLen, 5)
+	c.Assert(dataSlice[0], Matches, `ts=`+ts+` level=info caller=log/log_test.go:[0-9]+ msg="Test info 1" id=1 user_name="John Doe"`)
+	c.Assert(dataSlice[1], Matches, `ts=`+ts+` level=warn caller=log/log_test.go:[0-9]+ msg=Test empty="" dur=1s quote="a\\"b"`)
+	c.Assert(dataSlice[2], Matches, `ts=`+ts+` level=error caller=log/log_test.go:[0-9]+ msg="Test\\terror"`)
+	c.Assert(dataSlice[3], Matches, `ts=`+ts+` level=fatal caller=log/log_test.go:[0-9]+ ts=2024-01-01`)
+	c.Assert(dataSlice[4], Equals, `ts=`+ts+` level=info msg="Test aux"`)
+
+	c.Assert(getLevelName(DEBUG), Equals, "debug")
+	c.Assert(formatLogfmtKey("a=b c"), Equals, "a_b_c")
+	c.Assert(l.formatFieldValue(F{"a", 1}), Equals, "a:1")
+	c.Assert(l.formatFieldValue(3.14), Equals, "3.14")
+}
+
+func (ls *LogSuite) TestOTLP(c *C) {
+	logfile := ls.TempDir + "/otlp.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	l.Format = FORMAT_OTLP
+	l.MinLevel(DEBUG)
+
+	c.Assert(l.Divider(), IsNil)
+	c.Assert(l.Aux(""), IsNil)
+	c.Assert(l.Debug("Test debug %d", 1, F{"id", 1}, F{"user", "john"}), IsNil)
+
+	l.WithCaller = true
+
+	c.Assert(l.Info("Test info"), IsNil)
+	c.Assert(l.Warn("Test warn", F{"id", 2}), IsNil)
+
+	l.WithCaller = false
+	l.TimeLayout = time.RFC3339
+
+	c.Assert(l.Error("Test error"), IsNil)
+	c.Assert(l.Crit("Test crit"), IsNil)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+
+	type OTLPRecord struct {
+		Timestamp      any            `json:"timestamp"`
+		SeverityText   string         `json:"severity_text"`
+		SeverityNumber int            `json:"severity_number"`
+		Body           string         `json:"body"`
+		Attributes     map[string]any `json:"attributes"`
+	}
+
+	var records []*OTLPRecord
+
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		record := &OTLPRecord{}
+		c.Assert(json.Unmarshal([]byte(line), record), IsNil, Commentf("Invalid JSON: %s", line))
+		records = append(records, record)
+	}
+
+	c.Assert(records, HasLen, 5)
+
+	c.Assert(records[0].Timestamp, FitsTypeOf, float64(0))
+	c.Assert(records[0].SeverityText, Equals, "DEBUG")
+	c.Assert(records[0].SeverityNumber, Equals, 5)
+	c.Assert(records[0].Body, Equals, "Test debug 1")
+	c.Assert(records[0].Attributes["id"], Equals, float64(1))
+	c.Assert(records[0].Attributes["user"], Equals, "john")
+
+	c.Assert(records[1].SeverityText, Equals, "INFO")
+	c.Assert(records[1].SeverityNumber, Equals, 9)
+	c.Assert(records[1].Attributes["code.filepath"], Equals, "log/log_test.go")
+	c.Assert(records[1].Attributes["code.lineno"], FitsTypeOf, float64(0))
+
+	c.Assert(records[2].SeverityText, Equals, "WARN")
+	c.Assert(records[2].SeverityNumber, Equals, 13)
+	c.Assert(records[2].Attributes["id"], Equals, float64(2))
+
+	c.Assert(records[3].Timestamp, FitsTypeOf, "")
+	c.Assert(records[3].SeverityText, Equals, "ERROR")
+	c.Assert(records[3].SeverityNumber, Equals, 17)
+	c.Assert(records[3].Attributes, IsNil)
+
+	c.Assert(records[4].SeverityText, Equals, "FATAL")
+	c.Assert(records[4].SeverityNumber, Equals, 21)
+}
+
+func (ls *LogSuite) TestSinkFormat(c *C) {
+	var buf bytes.Buffer
+
+	l := &Logger{mu: &sync.Mutex{}}
+
+	c.Assert(l.AddSink(NewWriterSink(&buf), SinkConfig{Format: FORMAT_LOGFMT}), IsNil)
+	c.Assert(l.Info("Test info"), IsNil)
+	c.Assert(buf.String(), Matches, `ts=\S+ level=info msg="Test info"\n`)
+}
+
 func (ls *LogSuite) TestWithFields(c *C) {
 	logfile := ls.TempDir + "/fields.log"
 	l, err := New(logfile, 0644)
@@ -660,6 +787,433 @@ func (ls *LogSuite) TestBufIO(c *C) {
 	c.Assert(fsutil.GetSize(logfile), Not(Equals), fileSize)
 }
 
+func (ls *LogSuite) TestRotationBySize(c *C) {
+	logfile := ls.TempDir + "/rotation-size.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+	c.Assert(l.EnableRotation(Rotation{MaxSize: 64, MaxFiles: 2}), IsNil)
+
+	for i := range 8 {
+		l.Info("Test message %d", i)
+	}
+
+	c.Assert(fsutil.IsExist(logfile), Equals, true)
+	c.Assert(fsutil.IsExist(logfile+".1"), Equals, true)
+	c.Assert(fsutil.IsExist(logfile+".2"), Equals, true)
+	c.Assert(fsutil.IsExist(logfile+".3"), Equals, false)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.HasSuffix(string(data), "Test message 7\n"), Equals, true)
+
+	c.Assert(l.EnableRotation(Rotation{}), IsNil)
+	c.Assert(l.rotator, IsNil)
+}
+
+func (ls *LogSuite) TestRotationByPeriod(c *C) {
+	logfile := ls.TempDir + "/rotation-period.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	l.EnableBufIO(time.Minute)
+
+	c.Assert(l.EnableRotation(Rotation{Period: ROTATE_HOURLY, Compress: true}), IsNil)
+	c.Assert(l.rotator.next.After(time.Now()), Equals, true)
+	c.Assert(l.rotator.next.Sub(time.Now()) <= time.Hour, Equals, true)
+
+	l.Info("Test message 1")
+	l.rotator.next = time.Now().Add(-time.Second)
+	l.Info("Test message 2")
+	l.Flush()
+
+	l.rotator.wg.Wait()
+
+	c.Assert(fsutil.IsExist(logfile+".1"), Equals, false)
+	c.Assert(fsutil.IsExist(logfile+".1.gz"), Equals, true)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), "Test message 1"), Equals, false)
+	c.Assert(strings.Contains(string(data), "Test message 2"), Equals, true)
+
+	c.Assert(l.EnableRotation(Rotation{Period: ROTATE_DAILY}), IsNil)
+
+	next := l.rotator.next
+
+	c.Assert(next.Hour(), Equals, 0)
+	c.Assert(next.Minute(), Equals, 0)
+	c.Assert(next.After(time.Now()), Equals, true)
+
+	c.Assert(l.Rotate(), IsNil)
+	c.Assert(fsutil.IsExist(logfile+".1"), Equals, true)
+}
+
+func (ls *LogSuite) TestRotationCompressionFail(c *C) {
+	logfile := ls.TempDir + "/rotation-compress.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+	c.Assert(l.EnableRotation(Rotation{MaxSize: 1024, MaxFiles: 3, Compress: true}), IsNil)
+
+	// Compression fails if compressed file can't be created
+	c.Assert(os.WriteFile(logfile+".1", []byte("Old data\n"), 0644), IsNil)
+	c.Assert(os.MkdirAll(logfile+".1.gz/data", 0755), IsNil)
+
+	l.rotator.wg.Add(1)
+	l.rotator.compressFile(logfile+".1", 0644)
+
+	c.Assert(fsutil.IsExist(logfile+".1"), Equals, true)
+	c.Assert(os.RemoveAll(logfile+".1.gz"), IsNil)
+
+	l.Info("Test message 1")
+
+	c.Assert(l.Rotate(), IsNil)
+
+	l.rotator.wg.Wait()
+
+	c.Assert(fsutil.IsExist(logfile+".1.gz"), Equals, true)
+	c.Assert(fsutil.IsExist(logfile+".1"), Equals, false)
+
+	data, err := os.ReadFile(logfile + ".2")
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "Old data\n")
+}
+
+func (ls *LogSuite) TestRotationErrors(c *C) {
+	var l *Logger
+
+	c.Assert(l.EnableRotation(Rotation{MaxSize: 1}), Equals, ErrNilLogger)
+	c.Assert(l.Rotate(), Equals, ErrNilLogger)
+
+	l = &Logger{mu: &sync.Mutex{}}
+
+	c.Assert(l.EnableRotation(Rotation{Period: 10}), Equals, ErrUnexpectedPeriod)
+	c.Assert(l.EnableRotation(Rotation{MaxSize: 1}), Equals, ErrOutputNotSet)
+	c.Assert(l.Rotate(), Equals, ErrOutputNotSet)
+
+	c.Assert(EnableRotation(Rotation{MaxSize: 1}), Equals, ErrOutputNotSet)
+	c.Assert(Rotate(), Equals, ErrOutputNotSet)
+
+	logfile := ls.TempDir + "/rotation-fail.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	// Non-empty directory with the same name as rotated file breaks rename
+	c.Assert(os.MkdirAll(logfile+".1/data", 0755), IsNil)
+
+	l.EnableBufIO(time.Minute)
+
+	c.Assert(l.Info("Test message 1"), IsNil)
+	c.Assert(l.Rotate(), NotNil)
+	c.Assert(l.fd, NotNil)
+	c.Assert(l.w, NotNil)
+	c.Assert(l.Info("Test message 2"), IsNil)
+	c.Assert(l.Flush(), IsNil)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), "Test message 1"), Equals, true)
+	c.Assert(strings.Contains(string(data), "Test message 2"), Equals, true)
+}
+
+func (ls *LogSuite) TestSinks(c *C) {
+	logfile := ls.TempDir + "/sinks.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	var textBuf, jsonBuf bytes.Buffer
+
+	c.Assert(l.AddSink(NewWriterSink(&textBuf), SinkConfig{MinLevel: DEBUG}), IsNil)
+	c.Assert(l.AddSink(NewWriterSink(&jsonBuf), SinkConfig{MinLevel: 100, UseJSON: true}), IsNil)
+	c.Assert(l.AddSink(nil, SinkConfig{}), Equals, ErrNilSink)
+
+	l.WithCaller = true
+
+	c.Assert(l.Debug("Test debug %d", 1), IsNil)
+	c.Assert(l.Info("Test info %d", 2), IsNil)
+	c.Assert(l.Crit("Test crit %d", 3, F{"id", 1}), IsNil)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), "Test debug 1"), Equals, false)
+	c.Assert(strings.Contains(string(data), "Test info 2"), Equals, true)
+
+	textData := strings.Split(strings.TrimRight(textBuf.String(), "\n"), "\n")
+
+	c.Assert(textData, HasLen, 3)
+	c.Assert(textData[0][28:], Matches, `\(log/log_test.go:[0-9]+\) Test debug 1`)
+	c.Assert(textData[2][28:], Matches, `\(log/log_test.go:[0-9]+\) \[CRITICAL\] Test crit 3 \{id: 1\}`)
+
+	records := parseJSONRecords(strings.Split(strings.TrimRight(jsonBuf.String(), "\n"), "\n"))
+
+	c.Assert(records, HasLen, 1)
+	c.Assert(records[0].Level, Equals, "fatal")
+	c.Assert(records[0].Msg, Equals, "Test crit 3")
+	c.Assert(records[0].ID, Equals, 1)
+
+	c.Assert(l.Flush(), IsNil)
+	c.Assert(l.ResetSinks(), IsNil)
+	c.Assert(l.sinks, HasLen, 0)
+
+	fileSink, err := NewFileSink(ls.TempDir+"/sinks-file.log", 0644)
+
+	c.Assert(err, IsNil)
+	c.Assert(AddSink(fileSink, SinkConfig{MinLevel: WARN}), IsNil)
+
+	Info("Test info")
+	Warn("Test warn")
+
+	c.Assert(ResetSinks(), IsNil)
+	c.Assert(fileSink.Write(INFO, []byte("test")), Equals, ErrSinkClosed)
+
+	data, err = os.ReadFile(ls.TempDir + "/sinks-file.log")
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data)[28:], Equals, "[WARNING] Test warn\n")
+
+	_, err = NewFileSink("/_unknown_/test.log", 0644)
+	c.Assert(err, NotNil)
+
+	var ws *WriterSink
+
+	c.Assert(ws.Write(INFO, nil), Equals, ErrNilSink)
+	c.Assert(ws.Flush(), Equals, ErrNilSink)
+	c.Assert(ws.Close(), Equals, ErrNilSink)
+
+	var nl *Logger
+
+	c.Assert(nl.AddSink(fileSink, SinkConfig{}), Equals, ErrNilLogger)
+	c.Assert(nl.ResetSinks(), Equals, ErrNilLogger)
+}
+
+func (ls *LogSuite) TestSyslogSink(c *C) {
+	socket := ls.TempDir + "/syslog.sock"
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+
+	c.Assert(err, IsNil)
+
+	defer conn.Close()
+
+	syslogSockets = []string{ls.TempDir + "/unknown.sock"}
+	_, err = NewSyslogSink("", SYSLOG_USER)
+	c.Assert(err, Equals, ErrNoSyslogSocket)
+
+	syslogSockets = []string{socket}
+	sink, err := NewSyslogSink("myapp", SYSLOG_LOCAL0)
+	c.Assert(err, IsNil)
+
+	c.Assert(sink.Write(ERROR, []byte("Test error\n")), IsNil)
+	c.Assert(sink.Flush(), IsNil)
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(buf[:n]), Matches, `<131>1 \S+ \S+ myapp [0-9]+ - - Test error`)
+
+	// Syslog daemon restart
+	conn.Close()
+	os.Remove(socket)
+
+	c.Assert(sink.Write(ERROR, []byte("Lost error\n")), NotNil)
+	c.Assert(sink.Write(ERROR, []byte("Lost error\n")), Equals, ErrNoSyslogSocket)
+
+	conn, err = net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	c.Assert(err, IsNil)
+
+	c.Assert(sink.Write(ERROR, []byte("Test error 2\n")), IsNil)
+
+	n, err = conn.Read(buf)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(buf[:n]), Matches, `<131>1 \S+ \S+ myapp [0-9]+ - - Test error 2`)
+
+	c.Assert(sink.Close(), IsNil)
+	c.Assert(sink.Close(), IsNil)
+	c.Assert(sink.Write(ERROR, []byte("Test error\n")), Equals, ErrSinkClosed)
+
+	var ns *SyslogSink
+
+	c.Assert(ns.Write(INFO, nil), Equals, ErrNilSink)
+	c.Assert(ns.Flush(), Equals, ErrNilSink)
+	c.Assert(ns.Close(), Equals, ErrNilSink)
+
+	c.Assert(getSyslogSeverity(DEBUG), Equals, 7)
+	c.Assert(getSyslogSeverity(INFO), Equals, 6)
+	c.Assert(getSyslogSeverity(WARN), Equals, 4)
+	c.Assert(getSyslogSeverity(CRIT), Equals, 2)
+	c.Assert(getSyslogHeaderValue(""), Equals, "-")
+}
+
+func (ls *LogSuite) TestJournalSink(c *C) {
+	socket := ls.TempDir + "/journal.sock"
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+
+	c.Assert(err, IsNil)
+
+	defer conn.Close()
+
+	journalSocket = ls.TempDir + "/unknown.sock"
+	_, err = NewJournalSink("")
+	c.Assert(err, NotNil)
+
+	journalSocket = socket
+	sink, err := NewJournalSink("myapp")
+	c.Assert(err, IsNil)
+
+	c.Assert(sink.Write(WARN, []byte("Test warn\n")), IsNil)
+	c.Assert(sink.Flush(), IsNil)
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(buf[:n]), Equals, "PRIORITY=4\nSYSLOG_IDENTIFIER=myapp\nMESSAGE=Test warn\n")
+
+	c.Assert(sink.Write(INFO, []byte("A\nB\n")), IsNil)
+
+	n, err = conn.Read(buf)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(buf[:n]), Equals, "PRIORITY=6\nSYSLOG_IDENTIFIER=myapp\nMESSAGE\n\x03\x00\x00\x00\x00\x00\x00\x00A\nB\n")
+
+	c.Assert(sink.Close(), IsNil)
+	c.Assert(sink.Close(), IsNil)
+	c.Assert(sink.Write(ERROR, []byte("Test error\n")), Equals, ErrSinkClosed)
+
+	var ns *JournalSink
+
+	c.Assert(ns.Write(INFO, nil), Equals, ErrNilSink)
+	c.Assert(ns.Flush(), Equals, ErrNilSink)
+	c.Assert(ns.Close(), Equals, ErrNilSink)
+}
+
+func (ls *LogSuite) TestChildLogger(c *C) {
+	logfile := ls.TempDir + "/child.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	l.WithCaller = true
+
+	cl := l.With(F{"id", 1}, F{"", 2})
+
+	c.Assert(cl.Info("Test info %d", 1), IsNil)
+	c.Assert(cl.With(F{"user", "john"}).Warn("Test warn %d", 2, F{"ip", "127.0.0.1"}), IsNil)
+	c.Assert(cl.Debug("Test debug"), IsNil)
+	c.Assert(cl.Is(DEBUG), Equals, false)
+	c.Assert(cl.Is(INFO), Equals, true)
+
+	l.UseJSON = true
+
+	c.Assert(cl.With(F{"user", "bob"}).Error("Test error"), IsNil)
+	c.Assert(cl.Crit("Test crit"), IsNil)
+	c.Assert(cl.Aux("Test aux"), IsNil)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+
+	dataSlice := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	c.Assert(dataSlice, HasLen, 5)
+	c.Assert(dataSlice[0][28:], Matches, `\(log/log_test.go:[0-9]+\) Test info 1 \{id: 1\}`)
+	c.Assert(dataSlice[1][28:], Matches, `\(log/log_test.go:[0-9]+\) \[WARNING\] Test warn 2 \{ip: 127.0.0.1 \| id: 1 \| user: john\}`)
+
+	records := parseJSONRecords(dataSlice[2:])
+
+	c.Assert(records[0].Msg, Equals, "Test error")
+	c.Assert(records[0].ID, Equals, 1)
+	c.Assert(records[0].User, Equals, "bob")
+	c.Assert(records[0].Caller, Matches, `log/log_test.go:[0-9]+`)
+	c.Assert(records[1].Level, Equals, "fatal")
+	c.Assert(records[2].Level, Equals, "info")
+
+	var ncl *ChildLogger
+
+	c.Assert(ncl.Print(INFO, "test"), Equals, ErrNilLogger)
+	c.Assert(ncl.Is(INFO), Equals, false)
+	c.Assert(ncl.With(F{"id", 1}).Info("test"), Equals, ErrNilLogger)
+	c.Assert(With(F{"id", 1}).logger, Equals, Global)
+}
+
+func (ls *LogSuite) TestContext(c *C) {
+	cl := Global.With(F{"id", 1})
+	ctx := NewContext(context.Background(), cl)
+
+	c.Assert(FromContext(ctx), Equals, cl)
+	c.Assert(FromContext(context.Background()), Equals, Global)
+	c.Assert(FromContext(nil), Equals, Global)
+}
+
+func (ls *LogSuite) TestRateLimit(c *C) {
+	logfile := ls.TempDir + "/ratelimit.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+	c.Assert(l.SetRateLimit(ERROR, RateLimit{Rate: 0.1, Sample: 3, Interval: 100 * time.Millisecond}), IsNil)
+	c.Assert(l.SetRateLimit(ERROR, RateLimit{Rate: 0.1, Burst: 2, Sample: 3, Interval: 100 * time.Millisecond}), IsNil)
+
+	for i := range 10 {
+		l.Error("Test error %d", i)
+	}
+
+	l.Error("Another error")
+	l.Warn("Test warn")
+	l.Warn("Test warn")
+
+	time.Sleep(250 * time.Millisecond)
+
+	c.Assert(l.SetRateLimit(ERROR, RateLimit{}), IsNil)
+	c.Assert(l.limiters, HasLen, 0)
+
+	l.Error("Test error %d", 10)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+
+	dataSlice := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	c.Assert(dataSlice, HasLen, 9)
+	c.Assert(dataSlice[0][28:], Equals, "[ERROR] Test error 0")
+	c.Assert(dataSlice[1][28:], Equals, "[ERROR] Test error 1")
+	c.Assert(dataSlice[2][28:], Equals, "[ERROR] Test error 4")
+	c.Assert(dataSlice[3][28:], Equals, "[ERROR] Test error 7")
+	c.Assert(dataSlice[4][28:], Equals, "[ERROR] Another error")
+	c.Assert(dataSlice[5][28:], Equals, "[WARNING] Test warn")
+	c.Assert(dataSlice[6][28:], Equals, "[WARNING] Test warn")
+	c.Assert(dataSlice[7][28:], Equals, "[ERROR] 6 similar messages suppressed {message: Test error %d}")
+	c.Assert(dataSlice[8][28:], Equals, "[ERROR] Test error 10")
+
+	lim := &limiter{cfg: RateLimit{Rate: 1, Burst: 1}, buckets: map[string]*bucket{}}
+	now := time.Now()
+
+	c.Assert(lim.allow("test", now), Equals, true)
+	c.Assert(lim.allow("test", now), Equals, false)
+	c.Assert(lim.collect(now), DeepEquals, []string{"test"})
+	lim.buckets["test"].dropped = 0
+	c.Assert(lim.collect(now), HasLen, 0)
+	c.Assert(lim.buckets, HasLen, 1)
+	c.Assert(lim.collect(now.Add(2*time.Second)), HasLen, 0)
+	c.Assert(lim.buckets, HasLen, 0)
+
+	c.Assert(l.SetRateLimit(AUX, RateLimit{Rate: 1}), Equals, ErrUnexpectedLevel)
+	c.Assert(SetRateLimit(WARN, RateLimit{}), IsNil)
+
+	var nl *Logger
+	c.Assert(nl.SetRateLimit(ERROR, RateLimit{}), Equals, ErrNilLogger)
+}
+
 func (ls *LogSuite) TestLoggerIsNil(c *C) {
 	var l *Logger
 
@@ -938,6 +1492,49 @@ func (ls *LogSuite) TestPanicHandler(c *C) {
 	}, NotPanics)
 }
 
+func (ls *LogSuite) TestErrorFields(c *C) {
+	logfile := ls.TempDir + "/error-fields.log"
+	l, err := New(logfile, 0644)
+
+	c.Assert(err, IsNil)
+
+	l.UseJSON = true
+
+	cause := errors.NewError("connection refused").WithCode("E_CONN").
+		WithAttr("host", "db1").WithAttr("id", 42)
+	err = errors.Wrap(cause, "can't load user")
+
+	c.Assert(l.Error("Request failed", ErrorFields(err), F{"id", 101}), IsNil)
+	c.Assert(l.Error("Request failed", ErrorFields(errors.New("simple error"))), IsNil)
+	c.Assert(ErrorFields(nil).data, HasLen, 0)
+
+	data, err := os.ReadFile(logfile)
+
+	c.Assert(err, IsNil)
+
+	dataSlice := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	c.Assert(dataSlice, HasLen, 2)
+
+	var rec1, rec2 map[string]any
+
+	c.Assert(json.Unmarshal([]byte(dataSlice[0]), &rec1), IsNil)
+	c.Assert(json.Unmarshal([]byte(dataSlice[1]), &rec2), IsNil)
+
+	c.Assert(rec1["msg"], Equals, "Request failed")
+	c.Assert(rec1["error"], Equals, "can't load user: connection refused")
+	c.Assert(rec1["error.code"], Equals, "E_CONN")
+	c.Assert(rec1["error.host"], Equals, "db1")
+	c.Assert(rec1["error.id"], Equals, 42.0)
+	c.Assert(rec1["id"], Equals, 101.0)
+	c.Assert(strings.Count(dataSlice[0], `"id":`), Equals, 1)
+	c.Assert(rec1["error.stack"], Matches, "(?s).*log.\\(\\*LogSuite\\).TestErrorFields.*")
+
+	c.Assert(rec2["error"], Equals, "simple error")
+	c.Assert(rec2["error.code"], IsNil)
+	c.Assert(rec2["error.stack"], IsNil)
+}
+
 func (ls *LogSuite) TestPanicPathExtractor(c *C) {
 	stackTrace := `goroutine 43 [running]:
 runtime/debug.Stack()
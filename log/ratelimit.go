@@ -0,0 +1,205 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RateLimit contains rate limiting configuration for repeated messages. Messages
+// are considered similar if they have the same format string.
+type RateLimit struct {
+	Rate     float64       // Number of similar messages per second allowed to write
+	Burst    int           // Maximum number of similar messages written at once
+	Sample   int           // Write every N-th message exceeding the limit (0 = drop all)
+	Interval time.Duration // Interval between summary records (1 minute by default)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// limiter is token bucket rate limiter for messages with the same level
+type limiter struct {
+	cfg      RateLimit
+	buckets  map[string]*bucket
+	stopChan chan struct{}
+}
+
+// bucket contains rate limiting state for similar messages
+type bucket struct {
+	tokens     float64
+	lastUpdate time.Time
+	exceeded   int
+	dropped    int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetRateLimit sets rate limit for messages with given level for the global
+// logger. Passing empty configuration disables rate limiting for the level.
+func SetRateLimit(level uint8, limit RateLimit) error {
+	return Global.SetRateLimit(level, limit)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetRateLimit sets rate limit for messages with given level. Passing empty
+// configuration disables rate limiting for the level.
+func (l *Logger) SetRateLimit(level uint8, limit RateLimit) error {
+	if l == nil || l.mu == nil {
+		return ErrNilLogger
+	}
+
+	if level > CRIT {
+		return ErrUnexpectedLevel
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limiters[level] != nil {
+		close(l.limiters[level].stopChan)
+		delete(l.limiters, level)
+	}
+
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Ceil(limit.Rate))
+	}
+
+	if limit.Interval <= 0 {
+		limit.Interval = time.Minute
+	}
+
+	if l.limiters == nil {
+		l.limiters = make(map[uint8]*limiter)
+	}
+
+	lim := &limiter{
+		cfg:      limit,
+		buckets:  make(map[string]*bucket),
+		stopChan: make(chan struct{}),
+	}
+
+	l.limiters[level] = lim
+
+	go l.summaryDaemon(level, lim)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isAllowedByLimiter returns true if message with given level and format can
+// be written
+func (l *Logger) isAllowedByLimiter(level uint8, f string) bool {
+	lim := l.limiters[level]
+
+	if lim == nil {
+		return true
+	}
+
+	return lim.allow(f, time.Now())
+}
+
+// summaryDaemon periodically writes summary records about suppressed messages
+func (l *Logger) summaryDaemon(level uint8, lim *limiter) {
+	ticker := time.NewTicker(lim.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.writeSummary(level, lim)
+		case <-lim.stopChan:
+			return
+		}
+	}
+}
+
+// writeSummary writes summary records about suppressed messages
+func (l *Logger) writeSummary(level uint8, lim *limiter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Limiter was replaced or removed
+	if l.limiters[level] != lim {
+		return
+	}
+
+	for _, key := range lim.collect(time.Now()) {
+		l.write(
+			level, "%d similar messages suppressed", lim.buckets[key].dropped,
+			F{"message", key},
+		)
+
+		lim.buckets[key].dropped = 0
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// allow returns true if message with given key can be written
+func (lim *limiter) allow(key string, now time.Time) bool {
+	b := lim.buckets[key]
+
+	if b == nil {
+		b = &bucket{tokens: float64(lim.cfg.Burst), lastUpdate: now}
+		lim.buckets[key] = b
+	}
+
+	b.tokens = min(
+		float64(lim.cfg.Burst),
+		b.tokens+now.Sub(b.lastUpdate).Seconds()*lim.cfg.Rate,
+	)
+
+	b.lastUpdate = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+
+	b.exceeded++
+
+	if lim.cfg.Sample > 0 && b.exceeded%lim.cfg.Sample == 0 {
+		return true
+	}
+
+	b.dropped++
+
+	return false
+}
+
+// collect removes idle buckets and returns sorted slice with keys of buckets
+// with suppressed messages
+func (lim *limiter) collect(now time.Time) []string {
+	var result []string
+
+	for key, b := range lim.buckets {
+		if b.dropped > 0 {
+			result = append(result, key)
+			continue
+		}
+
+		// Bucket is full again, so we don't need it anymore
+		if b.tokens+now.Sub(b.lastUpdate).Seconds()*lim.cfg.Rate >= float64(lim.cfg.Burst) {
+			delete(lim.buckets, key)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
@@ -0,0 +1,304 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ROTATE_NEVER  uint8 = 0 // ROTATE_NEVER disables time-based rotation
+	ROTATE_HOURLY uint8 = 1 // ROTATE_HOURLY rotates log file at the beginning of every hour
+	ROTATE_DAILY  uint8 = 2 // ROTATE_DAILY rotates log file at midnight (local time)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rotation contains log rotation configuration
+type Rotation struct {
+	MaxSize  int64 // Maximum log file size in bytes (0 = no limit)
+	Period   uint8 // Time-based rotation period (ROTATE_HOURLY, ROTATE_DAILY)
+	MaxFiles int   // Number of rotated files to keep (at least 1)
+	Compress bool  // Compress rotated files with gzip
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rotator contains rotation state
+type rotator struct {
+	cfg  Rotation
+	size int64
+	next time.Time
+	wg   sync.WaitGroup
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrUnexpectedPeriod is returned by [EnableRotation] when rotation period
+	// is unknown
+	ErrUnexpectedPeriod = errors.New("unexpected rotation period")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EnableRotation enables built-in log rotation for the global logger
+func EnableRotation(cfg Rotation) error {
+	return Global.EnableRotation(cfg)
+}
+
+// Rotate forcibly rotates the global logger's output file
+func Rotate() error {
+	return Global.Rotate()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EnableRotation enables built-in log rotation by file size and/or time period.
+// Passing empty configuration disables rotation.
+func (l *Logger) EnableRotation(cfg Rotation) error {
+	if l == nil || l.mu == nil {
+		return ErrNilLogger
+	}
+
+	if cfg.Period > ROTATE_DAILY {
+		return ErrUnexpectedPeriod
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if cfg.MaxSize <= 0 && cfg.Period == ROTATE_NEVER {
+		if l.rotator != nil {
+			l.rotator.wg.Wait()
+		}
+
+		l.rotator = nil
+
+		return nil
+	}
+
+	if l.fd == nil {
+		return ErrOutputNotSet
+	}
+
+	cfg.MaxFiles = max(cfg.MaxFiles, 1)
+
+	if l.rotator != nil {
+		l.rotator.wg.Wait()
+	}
+
+	l.rotator = &rotator{cfg: cfg}
+	l.rotator.next = l.rotator.nextRotation(time.Now())
+	l.rotator.updateSize(l.fd)
+
+	return nil
+}
+
+// Rotate forcibly rotates the logger's output file
+func (l *Logger) Rotate() error {
+	if l == nil || l.mu == nil {
+		return ErrNilLogger
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.fd == nil {
+		return ErrOutputNotSet
+	}
+
+	return l.rotate(time.Now())
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rotateIfRequired rotates log file if size limit is reached or rotation
+// period is passed
+func (l *Logger) rotateIfRequired() error {
+	if l.rotator == nil || l.fd == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	if !l.rotator.isRequired(now) {
+		return nil
+	}
+
+	return l.rotate(now)
+}
+
+// rotate rotates log file
+func (l *Logger) rotate(now time.Time) error {
+	r := l.rotator
+
+	if r == nil {
+		r = &rotator{cfg: Rotation{MaxFiles: 1}}
+	}
+
+	if l.w != nil {
+		l.w.Flush()
+	}
+
+	l.fd.Close()
+	l.fd = nil
+
+	// Wait until previous rotated file is compressed
+	r.wg.Wait()
+
+	r.shiftFiles(l.file)
+
+	rotated := l.file + ".1"
+	err := os.Rename(l.file, rotated)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Reopen current file to keep writing into it
+		l.reopen()
+		return err
+	}
+
+	err = l.reopen()
+
+	if err != nil {
+		return err
+	}
+
+	r.size = 0
+	r.next = r.nextRotation(now)
+
+	if r.cfg.Compress {
+		r.wg.Add(1)
+		go r.compressFile(rotated, l.perms)
+	}
+
+	return nil
+}
+
+// reopen opens log file and resets buffered writer
+func (l *Logger) reopen() error {
+	fd, err := os.OpenFile(l.file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, l.perms)
+
+	if err != nil {
+		l.w = nil
+		return err
+	}
+
+	l.fd = fd
+
+	if l.w != nil {
+		l.w.Reset(l.fd)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isRequired returns true if log file must be rotated
+func (r *rotator) isRequired(now time.Time) bool {
+	switch {
+	case r.cfg.MaxSize > 0 && r.size >= r.cfg.MaxSize,
+		!r.next.IsZero() && !now.Before(r.next):
+		return true
+	}
+
+	return false
+}
+
+// nextRotation returns date of the next time-based rotation
+func (r *rotator) nextRotation(now time.Time) time.Time {
+	switch r.cfg.Period {
+	case ROTATE_HOURLY:
+		return time.Date(
+			now.Year(), now.Month(), now.Day(),
+			now.Hour()+1, 0, 0, 0, now.Location(),
+		)
+	case ROTATE_DAILY:
+		return time.Date(
+			now.Year(), now.Month(), now.Day()+1,
+			0, 0, 0, 0, now.Location(),
+		)
+	}
+
+	return time.Time{}
+}
+
+// updateSize updates size of current log file
+func (r *rotator) updateSize(fd *os.File) {
+	r.size = 0
+
+	if fd == nil {
+		return
+	}
+
+	fi, err := fd.Stat()
+
+	if err == nil {
+		r.size = fi.Size()
+	}
+}
+
+// shiftFiles renames rotated files (file.1 → file.2 …) and removes the
+// oldest ones. Both compressed and plain files are shifted, because rotated
+// file stays uncompressed if compression failed.
+func (r *rotator) shiftFiles(file string) {
+	for _, ext := range []string{"", ".gz"} {
+		os.Remove(file + "." + strconv.Itoa(r.cfg.MaxFiles) + ext)
+
+		for i := r.cfg.MaxFiles - 1; i > 0; i-- {
+			os.Rename(
+				file+"."+strconv.Itoa(i)+ext,
+				file+"."+strconv.Itoa(i+1)+ext,
+			)
+		}
+	}
+}
+
+// compressFile compresses rotated file with gzip
+func (r *rotator) compressFile(file string, perms os.FileMode) {
+	defer r.wg.Done()
+
+	src, err := os.Open(file)
+
+	if err != nil {
+		return
+	}
+
+	defer src.Close()
+
+	dst, err := os.OpenFile(file+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perms)
+
+	if err != nil {
+		return
+	}
+
+	gw := gzip.NewWriter(dst)
+	_, err = io.Copy(gw, src)
+
+	if err == nil {
+		err = gw.Close()
+	}
+
+	dst.Close()
+
+	if err != nil {
+		os.Remove(file + ".gz")
+		return
+	}
+
+	os.Remove(file)
+}
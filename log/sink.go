@@ -0,0 +1,219 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Sink is an additional output for log records
+type Sink interface {
+	// Write writes encoded record with given level
+	Write(level uint8, record []byte) error
+
+	// Flush writes any buffered data
+	Flush() error
+
+	// Close closes sink
+	Close() error
+}
+
+// SinkConfig contains sink configuration
+type SinkConfig struct {
+	MinLevel uint8 // Minimum level of messages written to sink
+	UseJSON  bool  // Encode messages to JSON (same as FORMAT_JSON)
+	Format   uint8 // Output format (FORMAT_TEXT, FORMAT_JSON, FORMAT_LOGFMT, FORMAT_OTLP)
+}
+
+// WriterSink is a sink that writes records to an [io.Writer]
+type WriterSink struct {
+	w  io.Writer
+	c  io.Closer
+	mu sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sinkEntry contains sink and its configuration
+type sinkEntry struct {
+	sink Sink
+	cfg  SinkConfig
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrNilSink is returned by [AddSink] if given sink is nil
+	ErrNilSink = errors.New("sink is nil")
+
+	// ErrSinkClosed is returned when writing to closed sink
+	ErrSinkClosed = errors.New("sink is closed")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AddSink adds an additional output to the global logger
+func AddSink(sink Sink, cfg SinkConfig) error {
+	return Global.AddSink(sink, cfg)
+}
+
+// ResetSinks closes and removes all sinks of the global logger
+func ResetSinks() error {
+	return Global.ResetSinks()
+}
+
+// NewWriterSink creates new sink for given writer
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// NewFileSink creates new sink for given file
+func NewFileSink(file string, perms os.FileMode) (*WriterSink, error) {
+	fd, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, perms)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriterSink{w: fd, c: fd}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AddSink adds an additional output to the logger. Every sink has its own minimum
+// level and format. If the logger has no output file and at least one sink, messages
+// are not written to stdout/stderr.
+func (l *Logger) AddSink(sink Sink, cfg SinkConfig) error {
+	if l == nil || l.mu == nil {
+		return ErrNilLogger
+	}
+
+	if sink == nil {
+		return ErrNilSink
+	}
+
+	if cfg.MinLevel > CRIT {
+		cfg.MinLevel = CRIT
+	}
+
+	l.mu.Lock()
+	l.sinks = append(l.sinks, &sinkEntry{sink, cfg})
+	l.mu.Unlock()
+
+	return nil
+}
+
+// ResetSinks closes and removes all sinks of the logger
+func (l *Logger) ResetSinks() error {
+	if l == nil || l.mu == nil {
+		return ErrNilLogger
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var err error
+
+	for _, s := range l.sinks {
+		s.sink.Flush()
+		closeErr := s.sink.Close()
+
+		if err == nil {
+			err = closeErr
+		}
+	}
+
+	l.sinks = nil
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes record to writer
+func (s *WriterSink) Write(level uint8, record []byte) error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w == nil {
+		return ErrSinkClosed
+	}
+
+	_, err := s.w.Write(record)
+
+	return err
+}
+
+// Flush flushes writer if it supports flushing
+func (s *WriterSink) Flush() error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// Close closes sink. The underlying writer is closed only if sink was created
+// using [NewFileSink].
+func (s *WriterSink) Close() error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+
+	if s.c != nil {
+		err = s.c.Close()
+	}
+
+	s.w, s.c = nil, nil
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getFormat returns output format of the sink
+func (c SinkConfig) getFormat() uint8 {
+	if c.Format == FORMAT_TEXT && c.UseJSON {
+		return FORMAT_JSON
+	}
+
+	return c.Format
+}
+
+// isSinkRequired returns true if at least one sink accepts messages with
+// given level
+func (l *Logger) isSinkRequired(level uint8) bool {
+	for _, s := range l.sinks {
+		if s.cfg.MinLevel <= level {
+			return true
+		}
+	}
+
+	return false
+}
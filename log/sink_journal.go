@@ -0,0 +1,128 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// JournalSink is a sink that sends records to the systemd journal using
+// native protocol
+type JournalSink struct {
+	identifier string
+	conn       net.Conn
+	buf        bytes.Buffer
+	mu         sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// journalSocket is path to journald native protocol socket
+var journalSocket = "/run/systemd/journal/socket"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewJournalSink creates new sink for systemd journal. If identifier is empty,
+// the name of the executable is used.
+func NewJournalSink(identifier string) (*JournalSink, error) {
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+
+	conn, err := net.Dial("unixgram", journalSocket)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &JournalSink{identifier: identifier, conn: conn}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write sends record to systemd journal
+func (s *JournalSink) Write(level uint8, record []byte) error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return ErrSinkClosed
+	}
+
+	s.buf.Reset()
+
+	s.writeField("PRIORITY", []byte(strconv.Itoa(getSyslogSeverity(level))))
+	s.writeField("SYSLOG_IDENTIFIER", []byte(s.identifier))
+	s.writeField("MESSAGE", bytes.TrimRight(record, "\n"))
+
+	_, err := s.conn.Write(s.buf.Bytes())
+
+	s.buf.Reset()
+
+	return err
+}
+
+// Flush does nothing because journal sink is unbuffered
+func (s *JournalSink) Flush() error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	return nil
+}
+
+// Close closes connection to journal socket
+func (s *JournalSink) Close() error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeField writes field in journal native format into buffer
+func (s *JournalSink) writeField(name string, value []byte) {
+	s.buf.WriteString(name)
+
+	if bytes.IndexByte(value, '\n') == -1 {
+		s.buf.WriteRune('=')
+		s.buf.Write(value)
+		s.buf.WriteRune('\n')
+		return
+	}
+
+	// Values with line breaks must be encoded as binary data with size prefix
+	s.buf.WriteRune('\n')
+	binary.Write(&s.buf, binary.LittleEndian, uint64(len(value)))
+	s.buf.Write(value)
+	s.buf.WriteRune('\n')
+}
@@ -0,0 +1,228 @@
+package log
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	SYSLOG_USER   uint8 = 1  // SYSLOG_USER is user-level messages facility
+	SYSLOG_DAEMON uint8 = 3  // SYSLOG_DAEMON is system daemons facility
+	SYSLOG_LOCAL0 uint8 = 16 // SYSLOG_LOCAL0 is local use 0 facility
+	SYSLOG_LOCAL1 uint8 = 17 // SYSLOG_LOCAL1 is local use 1 facility
+	SYSLOG_LOCAL2 uint8 = 18 // SYSLOG_LOCAL2 is local use 2 facility
+	SYSLOG_LOCAL3 uint8 = 19 // SYSLOG_LOCAL3 is local use 3 facility
+	SYSLOG_LOCAL4 uint8 = 20 // SYSLOG_LOCAL4 is local use 4 facility
+	SYSLOG_LOCAL5 uint8 = 21 // SYSLOG_LOCAL5 is local use 5 facility
+	SYSLOG_LOCAL6 uint8 = 22 // SYSLOG_LOCAL6 is local use 6 facility
+	SYSLOG_LOCAL7 uint8 = 23 // SYSLOG_LOCAL7 is local use 7 facility
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SyslogSink is a sink that sends records to the local syslog daemon using
+// RFC 5424 format
+type SyslogSink struct {
+	tag      string
+	facility uint8
+	hostname string
+	pid      string
+	conn     net.Conn
+	buf      bytes.Buffer
+	closed   bool
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrNoSyslogSocket is returned by [NewSyslogSink] if local syslog socket
+// not found
+var ErrNoSyslogSocket = errors.New("can't find local syslog socket")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// syslogSockets is a list of paths to local syslog sockets
+var syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewSyslogSink creates new sink for local syslog daemon. If tag is empty, the
+// name of the executable is used.
+func NewSyslogSink(tag string, facility uint8) (*SyslogSink, error) {
+	if tag == "" {
+		tag = filepath.Base(os.Args[0])
+	}
+
+	hostname, _ := os.Hostname()
+
+	s := &SyslogSink{
+		tag:      tag,
+		facility: facility,
+		hostname: hostname,
+		pid:      strconv.Itoa(os.Getpid()),
+	}
+
+	err := s.connect()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write sends record to syslog. If syslog daemon is not available (e.g. it is
+// restarting), the record is dropped and the sink tries to reconnect on the next
+// write.
+func (s *SyslogSink) Write(level uint8, record []byte) error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrSinkClosed
+	}
+
+	s.encode(level, record)
+	defer s.buf.Reset()
+
+	if s.conn != nil {
+		_, err := s.conn.Write(s.buf.Bytes())
+
+		if err == nil {
+			return nil
+		}
+
+		// Syslog daemon could be restarted, so we try to reconnect
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	err := s.connect()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s.conn.Write(s.buf.Bytes())
+
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return err
+}
+
+// Flush does nothing because syslog sink is unbuffered
+func (s *SyslogSink) Flush() error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	return nil
+}
+
+// Close closes connection to syslog daemon
+func (s *SyslogSink) Close() error {
+	if s == nil {
+		return ErrNilSink
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// connect connects to local syslog socket
+func (s *SyslogSink) connect() error {
+	for _, socket := range syslogSockets {
+		conn, err := net.Dial("unixgram", socket)
+
+		if err == nil {
+			s.conn = conn
+			return nil
+		}
+	}
+
+	s.conn = nil
+
+	return ErrNoSyslogSocket
+}
+
+// encode encodes record to RFC 5424 message
+func (s *SyslogSink) encode(level uint8, record []byte) {
+	s.buf.Reset()
+
+	s.buf.WriteRune('<')
+	s.buf.WriteString(strconv.Itoa(int(s.facility)*8 + getSyslogSeverity(level)))
+	s.buf.WriteString(">1 ")
+	s.buf.WriteString(time.Now().Format("2006-01-02T15:04:05.000000Z07:00"))
+	s.buf.WriteRune(' ')
+	s.buf.WriteString(getSyslogHeaderValue(s.hostname))
+	s.buf.WriteRune(' ')
+	s.buf.WriteString(getSyslogHeaderValue(s.tag))
+	s.buf.WriteRune(' ')
+	s.buf.WriteString(s.pid)
+	s.buf.WriteString(" - - ")
+	s.buf.Write(bytes.TrimRight(record, "\n"))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getSyslogSeverity converts log level to syslog severity
+func getSyslogSeverity(level uint8) int {
+	switch level {
+	case DEBUG:
+		return 7
+	case WARN:
+		return 4
+	case ERROR:
+		return 3
+	case CRIT:
+		return 2
+	}
+
+	return 6
+}
+
+// getSyslogHeaderValue returns header value or NILVALUE if value is empty
+func getSyslogHeaderValue(v string) string {
+	if v == "" {
+		return "-"
+	}
+
+	return v
+}
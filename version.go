@@ -8,4 +8,4 @@ package ek
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // VERSION is current ek package version
-const VERSION = "14.4.2"
+const VERSION = "14.5.0"